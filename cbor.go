@@ -0,0 +1,495 @@
+package gosl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// MarshalCBOR converts struct *T to CBOR (RFC 8949) data (byte slice) with the
+// same rules (and the "json" struct tags) as the Marshal function.
+//
+// If err != nil returns zero-value for a byte slice and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		u := &user{ID: 1, Name: "Viktor"}
+//
+//		data, err := gosl.MarshalCBOR(u)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(data)
+//	}
+func MarshalCBOR[T any](model *T) ([]byte, error) {
+	w := &cborWriter{buf: make([]byte, 0, 64)}
+
+	if err := encodeBinaryValue(w, reflect.ValueOf(model)); err != nil {
+		return nil, err
+	}
+
+	return w.buf, nil
+}
+
+// UnmarshalCBOR converts CBOR (RFC 8949) data (byte slice) to struct *T with
+// the same rules (and the "json" struct tags) as the Unmarshal function.
+//
+// If err != nil returns zero-value for a struct and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		data := []byte{0xa2, 0x62, 0x69, 0x64, 0x01, 0x64, 0x6e, 0x61, 0x6d, 0x65, 0x66, 0x56, 0x69, 0x6b, 0x74, 0x6f, 0x72}
+//		model := &user{}
+//
+//		u, err := gosl.UnmarshalCBOR(data, model)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(u)
+//	}
+func UnmarshalCBOR[T any](data []byte, model *T) (*T, error) {
+	r := &cborReader{data: data}
+
+	src, err := r.readValue(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check, if data has bytes after the value.
+	if r.pos != len(r.data) {
+		return nil, errors.New("error: invalid CBOR data, unexpected bytes after top-level value")
+	}
+
+	if err = assignBinaryValue(reflect.ValueOf(&model).Elem(), src); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CBOR major types.
+const (
+	cborUint byte = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// cborWriter represents a writer of the CBOR format.
+type cborWriter struct {
+	buf []byte
+}
+
+// writeHead writes the initial byte with the major type and the argument.
+func (w *cborWriter) writeHead(major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		w.buf = append(w.buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		w.buf = append(w.buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, major|26), uint32(arg))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, major|27), arg)
+	}
+}
+
+func (w *cborWriter) writeNil() {
+	w.buf = append(w.buf, cborSimple|22)
+}
+
+func (w *cborWriter) writeBool(b bool) {
+	if b {
+		w.buf = append(w.buf, cborSimple|21)
+	} else {
+		w.buf = append(w.buf, cborSimple|20)
+	}
+}
+
+func (w *cborWriter) writeInt(i int64) {
+	if i >= 0 {
+		w.writeHead(cborUint, uint64(i))
+	} else {
+		w.writeHead(cborNegInt, uint64(^i)) // -1 - i
+	}
+}
+
+func (w *cborWriter) writeUint(u uint64) {
+	w.writeHead(cborUint, u)
+}
+
+func (w *cborWriter) writeFloat32(f float32) {
+	w.buf = binary.BigEndian.AppendUint32(append(w.buf, cborSimple|26), math.Float32bits(f))
+}
+
+func (w *cborWriter) writeFloat64(f float64) {
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, cborSimple|27), math.Float64bits(f))
+}
+
+func (w *cborWriter) writeString(s string) {
+	w.writeHead(cborText, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *cborWriter) writeBytes(b []byte) {
+	w.writeHead(cborBytes, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *cborWriter) writeArrayHeader(n int) {
+	w.writeHead(cborArray, uint64(n))
+}
+
+func (w *cborWriter) writeMapHeader(n int) {
+	w.writeHead(cborMap, uint64(n))
+}
+
+// cborBreak represents the "break" stop code of the indefinite-length items.
+type cborBreak struct{}
+
+// cborReader represents a reader of the CBOR format.
+type cborReader struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next n bytes of the data.
+func (r *cborReader) next(n uint64) ([]byte, error) {
+	if uint64(len(r.data)-r.pos) < n {
+		return nil, errBinaryUnexpectedEnd
+	}
+
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+
+	return b, nil
+}
+
+// readHead reads the initial byte and the argument. For the indefinite-length
+// items returns true for a bool.
+func (r *cborReader) readHead() (major, info byte, arg uint64, indefinite bool, err error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+
+	major, info = b[0]&0xe0, b[0]&0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), false, nil
+	case info <= 27:
+		p, err := r.next(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, false, err
+		}
+
+		for _, x := range p {
+			arg = arg<<8 | uint64(x)
+		}
+
+		return major, info, arg, false, nil
+	case info == 31:
+		switch major {
+		case cborBytes, cborText, cborArray, cborMap, cborSimple:
+			return major, info, 0, true, nil
+		}
+	}
+
+	return 0, 0, 0, false, fmt.Errorf("error: invalid CBOR data, malformed initial byte 0x%02x", b[0])
+}
+
+// readValue reads the next value of the data to the generic Go value.
+func (r *cborReader) readValue(depth int) (any, error) {
+	v, err := r.readItem(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := v.(cborBreak); ok {
+		return nil, errors.New("error: invalid CBOR data, unexpected break stop code")
+	}
+
+	return v, nil
+}
+
+// readItem reads the next item of the data (including the "break" stop code).
+func (r *cborReader) readItem(depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, errors.New("error: invalid CBOR data, exceeded max depth")
+	}
+
+	major, info, arg, indefinite, err := r.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint:
+		if arg <= math.MaxInt64 {
+			return int64(arg), nil
+		}
+
+		return arg, nil
+	case cborNegInt:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("error: invalid CBOR data, negative integer overflows int64")
+		}
+
+		return -1 - int64(arg), nil
+	case cborBytes, cborText:
+		var p []byte
+		if indefinite {
+			// Concatenate definite-length chunks of the same major type.
+			p = []byte{}
+			for {
+				if r.pos < len(r.data) && r.data[r.pos] == cborSimple|31 {
+					r.pos++
+					break
+				}
+
+				chunkMajor, _, n, chunkIndefinite, err := r.readHead()
+				if err != nil {
+					return nil, err
+				}
+
+				if chunkMajor != major || chunkIndefinite {
+					return nil, errors.New("error: invalid CBOR data, wrong chunk of indefinite-length string")
+				}
+
+				chunk, err := r.next(n)
+				if err != nil {
+					return nil, err
+				}
+				p = append(p, chunk...)
+			}
+		} else {
+			chunk, err := r.next(arg)
+			if err != nil {
+				return nil, err
+			}
+			p = append([]byte(nil), chunk...)
+		}
+
+		if major == cborText {
+			return string(p), nil
+		}
+
+		return p, nil
+	case cborArray:
+		if indefinite {
+			a := []any{}
+			for {
+				v, err := r.readItem(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+
+				if _, ok := v.(cborBreak); ok {
+					return a, nil
+				}
+				a = append(a, v)
+			}
+		}
+
+		// Each element takes at least one byte.
+		if arg > uint64(len(r.data)-r.pos) {
+			return nil, errBinaryUnexpectedEnd
+		}
+
+		a := make([]any, arg)
+		for i := range a {
+			if a[i], err = r.readValue(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+
+		return a, nil
+	case cborMap:
+		if !indefinite && arg > uint64(len(r.data)-r.pos)/2 {
+			return nil, errBinaryUnexpectedEnd
+		}
+
+		m := make(map[string]any)
+		for i := uint64(0); indefinite || i < arg; i++ {
+			k, err := r.readItem(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := k.(cborBreak); ok && indefinite {
+				break
+			}
+
+			key, err := cborMapKey(k)
+			if err != nil {
+				return nil, err
+			}
+
+			if m[key], err = r.readValue(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+
+		return m, nil
+	case cborTag:
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return cborTagValue(arg, v)
+	default:
+		return cborSimpleValue(info, arg, indefinite)
+	}
+}
+
+// cborMapKey converts the decoded key of the map to string.
+func cborMapKey(k any) (string, error) {
+	switch kv := k.(type) {
+	case string:
+		return kv, nil
+	case int64:
+		return strconv.FormatInt(kv, 10), nil
+	case uint64:
+		return strconv.FormatUint(kv, 10), nil
+	default:
+		return "", fmt.Errorf("error: invalid CBOR data, unsupported map key type %T", k)
+	}
+}
+
+// cborTagValue converts the tagged value. Supports standard date/time (0),
+// epoch-based date/time (1) and bignums (2, 3), other tags are ignored.
+func cborTagValue(tag uint64, v any) (any, error) {
+	switch tag {
+	case 0:
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("error: invalid CBOR data, tag 0 must contain a text string")
+		}
+
+		return time.Parse(time.RFC3339Nano, s)
+	case 1:
+		switch n := v.(type) {
+		case int64:
+			return time.Unix(n, 0).UTC(), nil
+		case uint64:
+			return time.Unix(int64(n), 0).UTC(), nil
+		case float64:
+			sec, frac := math.Modf(n)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		default:
+			return nil, errors.New("error: invalid CBOR data, tag 1 must contain a number")
+		}
+	case 2, 3:
+		p, ok := v.([]byte)
+		if !ok {
+			return nil, errors.New("error: invalid CBOR data, bignum tag must contain a byte string")
+		}
+
+		n := new(big.Int).SetBytes(p)
+		if tag == 3 {
+			n.Neg(n).Sub(n, big.NewInt(1)) // -1 - n
+		}
+
+		switch {
+		case n.IsInt64():
+			return n.Int64(), nil
+		case n.IsUint64():
+			return n.Uint64(), nil
+		default:
+			return nil, errors.New("error: invalid CBOR data, bignum overflows 64-bit integer")
+		}
+	default:
+		return v, nil
+	}
+}
+
+// cborSimpleValue converts the simple value or the float of the major type 7.
+func cborSimpleValue(info byte, arg uint64, indefinite bool) (any, error) {
+	if indefinite {
+		return cborBreak{}, nil
+	}
+
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil // null and undefined
+	case 25:
+		return float64(float16ToFloat32(uint16(arg))), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case 27:
+		return math.Float64frombits(arg), nil
+	default:
+		return nil, fmt.Errorf("error: invalid CBOR data, unsupported simple value %d", arg)
+	}
+}
+
+// float16ToFloat32 converts IEEE 754 half-precision float bits to float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0:
+		// Zero or subnormal number.
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			return -f
+		}
+		return f
+	case 0x1f:
+		// Infinity or NaN.
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+	}
+}
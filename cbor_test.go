@@ -0,0 +1,209 @@
+package gosl
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkMarshalCBOR_StructField_4(b *testing.B) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"-"`
+	}
+
+	u := &user{ID: 1, Name: "Viktor", Email: "my@mail.com"}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = MarshalCBOR(u)
+	}
+}
+
+func BenchmarkUnmarshalCBOR_StructField_4(b *testing.B) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"-"`
+	}
+
+	d, _ := MarshalCBOR(&user{ID: 1, Name: "Viktor", Email: "my@mail.com"})
+	u := &user{}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = UnmarshalCBOR(d, u)
+	}
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"-"`
+	}
+
+	u := &user{ID: 1, Name: "Viktor"}
+	expected := []byte{0xa2, 0x62, 'i', 'd', 0x01, 0x64, 'n', 'a', 'm', 'e', 0x66, 'V', 'i', 'k', 't', 'o', 'r'}
+
+	data, err := MarshalCBOR(u)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, data)
+
+	data, err = MarshalCBOR[user](nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, []byte{0xf6}, data)
+
+	_, err = MarshalCBOR(&struct{ F func() }{F: func() {}})
+	require.Error(t, err)
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	data, err = g.MarshalCBOR(u)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, data)
+}
+
+func TestMarshalCBOR_RFCExamples(t *testing.T) {
+	// Examples from the Appendix A of the RFC 8949.
+	for _, tc := range []struct {
+		value    any
+		expected string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{uint64(18446744073709551615), "1bffffffffffffffff"},
+		{-1, "20"},
+		{-1000, "3903e7"},
+		{1.1, "fb3ff199999999999a"},
+		{float32(100000.0), "fa47c35000"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"IETF", "6449455446"},
+		{"ü", "62c3bc"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]any{1, []any{2, 3}, []any{4, 5}}, "8301820203820405"},
+		{map[string]any{"a": 1, "b": []any{2, 3}}, "a26161016162820203"},
+	} {
+		data, err := MarshalCBOR(&tc.value)
+		require.NoError(t, err)
+		assert.EqualValues(t, tc.expected, hex.EncodeToString(data), "value %v", tc.value)
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"-"`
+	}
+
+	data := []byte{0xa2, 0x62, 'i', 'd', 0x01, 0x64, 'n', 'a', 'm', 'e', 0x66, 'V', 'i', 'k', 't', 'o', 'r'}
+
+	_, err := UnmarshalCBOR(nil, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR(data[:len(data)-1], &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR(append(data, 0x00), &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR([]byte{0xff}, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR([]byte{0x1c}, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR([]byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, &user{})
+	require.Error(t, err)
+
+	u, err := UnmarshalCBOR(data, &user{})
+	require.NoError(t, err)
+	assert.EqualValues(t, &user{ID: 1, Name: "Viktor"}, u)
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	_, err = g.UnmarshalCBOR(nil, &user{})
+	require.Error(t, err)
+
+	u, err = g.UnmarshalCBOR(data, &user{})
+	require.NoError(t, err)
+	assert.EqualValues(t, &user{ID: 1, Name: "Viktor"}, u)
+}
+
+func TestUnmarshalCBOR_RFCExamples(t *testing.T) {
+	// Examples from the Appendix A of the RFC 8949.
+	for _, tc := range []struct {
+		data     string
+		expected any
+	}{
+		{"f93c00", 1.0},
+		{"f97bff", 65504.0},
+		{"f90001", 5.960464477539063e-08},
+		{"f9c400", -4.0},
+		{"f97c00", math.Inf(1)},
+		{"3bffffffffffffffff", nil}, // overflows int64
+		{"c249010000000000000000", nil},
+		{"c11a514b67b0", time.Unix(1363896240, 0).UTC()},
+		{"c074323031332d30332d32315432303a30343a30305a", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"d82076687474703a2f2f7777772e6578616d706c652e636f6d", "http://www.example.com"},
+		{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9f018202039f0405ffff", []any{1.0, []any{2.0, 3.0}, []any{4.0, 5.0}}},
+		{"bf61610161629f0203ffff", map[string]any{"a": 1.0, "b": []any{2.0, 3.0}}},
+		{"a201020304", map[string]any{"1": 2.0, "3": 4.0}},
+	} {
+		data, err := hex.DecodeString(tc.data)
+		require.NoError(t, err)
+
+		var v any
+		out, err := UnmarshalCBOR(data, &v)
+		if tc.expected == nil {
+			require.Error(t, err, "data %s", tc.data)
+			continue
+		}
+
+		require.NoError(t, err, "data %s", tc.data)
+		assert.EqualValues(t, tc.expected, *out, "data %s", tc.data)
+	}
+}
+
+func TestCBOR_RoundTripJSON(t *testing.T) {
+	m := newBinaryModel()
+
+	data, err := MarshalCBOR(m)
+	require.NoError(t, err)
+
+	out, err := UnmarshalCBOR(data, &binaryModel{})
+	require.NoError(t, err)
+
+	expected, err := Marshal(m)
+	require.NoError(t, err)
+
+	actual, err := Marshal(out)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Empty(t, out.Password)
+
+	// Decode into the generic map, like the Unmarshal function does.
+	fromBinary, err := UnmarshalCBOR(data, &map[string]any{})
+	require.NoError(t, err)
+
+	fromJSON, err := Unmarshal(expected, &map[string]any{})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, *fromJSON, *fromBinary)
+}
@@ -0,0 +1,125 @@
+// Command gosl provides a command line interface for some snippets of the
+// gosl package.
+//
+// Usage:
+//
+//	gosl gen-struct [flags] file...
+//
+// The gen-struct command generates Go struct definitions from the sample JSON,
+// YAML or TOML documents. If no files are given, a sample is read from stdin
+// (the -format flag is required in this case).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/koddr/gosl"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command with the given arguments.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: gosl gen-struct [flags] file...")
+	}
+
+	switch args[0] {
+	case "gen-struct":
+		return runGenStruct(args[1:], stdin, stdout)
+	default:
+		return fmt.Errorf("error: unknown command %q, use: gen-struct", args[0])
+	}
+}
+
+// runGenStruct runs the gen-struct command with the given arguments.
+func runGenStruct(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gen-struct", flag.ContinueOnError)
+	name := fs.String("name", "Model", "name of the generated struct")
+	pkg := fs.String("package", "main", "name of the package for the generated file")
+	fileFormat := fs.String("format", "", "format of the samples (json, yaml or toml), by default from the file extension")
+	output := fs.String("output", "", "path to the output file, by default stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Read the sample documents.
+	var samples [][]byte
+
+	if fs.NArg() == 0 {
+		if *fileFormat == "" {
+			return errors.New("error: format of the samples from stdin is not set, use the -format flag")
+		}
+
+		sample, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("error reading sample from stdin, %w", err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	for _, path := range fs.Args() {
+		// Check, if all samples have the same format.
+		ext := strings.TrimPrefix(filepath.Ext(path), ".")
+		if *fileFormat == "" {
+			*fileFormat = ext
+		} else if ext != "" && !sameSampleFormat(*fileFormat, ext) {
+			return fmt.Errorf("error: sample file (%s) has a different format, expected %s", path, *fileFormat)
+		}
+
+		sample, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return fmt.Errorf("error reading sample file (%s), %w", path, err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	// Generate struct definitions.
+	src, err := gosl.GenerateStruct(*name, *fileFormat, samples...)
+	if err != nil {
+		return err
+	}
+
+	header := gosl.Concat("// Code generated by gosl gen-struct. DO NOT EDIT.\n\npackage ", *pkg, "\n\n")
+	if strings.Contains(src, "time.Time") {
+		header = gosl.Concat(header, "import \"time\"\n\n")
+	}
+
+	// Write the generated file.
+	if *output == "" {
+		_, err = io.WriteString(stdout, gosl.Concat(header, src))
+		return err
+	}
+
+	return os.WriteFile(*output, []byte(gosl.Concat(header, src)), 0o600)
+}
+
+// sameSampleFormat reports whether the given formats are the same.
+func sameSampleFormat(a, b string) bool {
+	normalize := func(s string) string {
+		switch s = strings.ToLower(s); s {
+		case "yml":
+			return "yaml"
+		case "jsonl", "ndjson":
+			return "json"
+		default:
+			return s
+		}
+	}
+
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+
+	require.Error(t, run(nil, nil, &out))
+	require.Error(t, run([]string{"unknown"}, nil, &out))
+}
+
+func TestRunGenStruct(t *testing.T) {
+	dir := t.TempDir()
+
+	sample1 := filepath.Join(dir, "user1.json")
+	require.NoError(t, os.WriteFile(sample1, []byte(`{"id":1,"created_at":"2023-05-01T12:30:00Z"}`), 0o600))
+
+	sample2 := filepath.Join(dir, "user2.json")
+	require.NoError(t, os.WriteFile(sample2, []byte(`{"id":2,"name":"Anna"}`), 0o600))
+
+	sample3 := filepath.Join(dir, "user3.yaml")
+	require.NoError(t, os.WriteFile(sample3, []byte(`id: 3`), 0o600))
+
+	var out bytes.Buffer
+
+	err := run([]string{"gen-struct", "-name", "User", "-package", "models", sample1, sample2}, nil, &out)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out.String(), "// Code generated by gosl gen-struct. DO NOT EDIT.\n\npackage models\n\nimport \"time\"\n\n"))
+	assert.Contains(t, out.String(), "type User struct {")
+	assert.Contains(t, out.String(), "Name      *string    `json:\"name,omitempty\" koanf:\"name\"`")
+
+	out.Reset()
+
+	err = run([]string{"gen-struct", "-format", "json"}, strings.NewReader(`{"id":1}`), &out)
+	require.NoError(t, err)
+	assert.NotContains(t, out.String(), "import")
+	assert.Contains(t, out.String(), "ID int `json:\"id\" koanf:\"id\"`")
+
+	output := filepath.Join(dir, "user.go")
+	err = run([]string{"gen-struct", "-output", output, sample1}, nil, &out)
+	require.NoError(t, err)
+	assert.FileExists(t, output)
+
+	require.Error(t, run([]string{"gen-struct"}, strings.NewReader(`{}`), &out))
+	require.Error(t, run([]string{"gen-struct", "-unknown"}, nil, &out))
+	require.Error(t, run([]string{"gen-struct", sample1, sample3}, nil, &out))
+	require.Error(t, run([]string{"gen-struct", filepath.Join(dir, "not-found.json")}, nil, &out))
+}
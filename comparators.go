@@ -1,5 +1,14 @@
 package gosl
 
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 // Equals compares two values of type T, returns true if they are equal.
 //
 // Example:
@@ -47,3 +56,362 @@ func Equals[T comparable](value1, value2 T) bool {
 func NotEquals[T comparable](value1, value2 T) bool {
 	return value1 != value2
 }
+
+// JSONDiffOp represents a type of the change in the structural JSON diff.
+type JSONDiffOp string
+
+// Types of the changes in the structural JSON diff.
+const (
+	JSONDiffAdded    JSONDiffOp = "added"
+	JSONDiffRemoved  JSONDiffOp = "removed"
+	JSONDiffModified JSONDiffOp = "modified"
+)
+
+// JSONChange represents a single change in the structural JSON diff. The path
+// of the change is a JSON Pointer (RFC 6901).
+type JSONChange struct {
+	Op       JSONDiffOp `json:"op"`
+	Path     string     `json:"path"`
+	OldValue any        `json:"old_value"`
+	NewValue any        `json:"new_value"`
+}
+
+// DiffOptions represents options for the DiffJSON and DiffValues functions.
+//
+// IgnorePaths contains JSON Pointers to ignore (with all nested values), the
+// "*" segment matches any key or index (for ex., "/items/*/updated_at"). If
+// UnorderedArrays is true, arrays are compared as multisets.
+type DiffOptions struct {
+	IgnorePaths     []string
+	UnorderedArrays bool
+}
+
+// DiffJSON compares two JSON documents and returns a list of the changes
+// (added, removed and modified values with JSON Pointer paths) from a to b.
+//
+// If err != nil returns zero-value for a slice and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		a := []byte(`{"id":1,"name":"Viktor","tags":["admin"]}`)
+//		b := []byte(`{"id":1,"name":"Vic","email":"my@mail.com"}`)
+//
+//		changes, err := gosl.DiffJSON(a, b)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(changes)
+//	}
+func DiffJSON(a, b []byte, opts ...DiffOptions) ([]JSONChange, error) {
+	var va, vb any
+
+	if err := jsonNumberAPI.Unmarshal(a, &va); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the first JSON document, %w", err)
+	}
+
+	if err := jsonNumberAPI.Unmarshal(b, &vb); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the second JSON document, %w", err)
+	}
+
+	d := &jsonDiffer{changes: []JSONChange{}}
+	for _, opt := range opts {
+		d.ignore = append(d.ignore, opt.IgnorePaths...)
+		d.unordered = d.unordered || opt.UnorderedArrays
+	}
+
+	d.diff("", va, vb)
+
+	return d.changes, nil
+}
+
+// DiffValues compares JSON representations of two values of type *T and
+// returns a list of the changes from a to b, like the DiffJSON function does.
+//
+// If err != nil returns zero-value for a slice and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		a := &user{ID: 1, Name: "Viktor"}
+//		b := &user{ID: 1, Name: "Vic"}
+//
+//		changes, err := gosl.DiffValues(a, b)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(changes)
+//	}
+func DiffValues[T any](a, b *T, opts ...DiffOptions) ([]JSONChange, error) {
+	ja, err := Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	jb, err := Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffJSON(ja, jb, opts...)
+}
+
+// jsonDiffer represents a state of the DiffJSON function.
+type jsonDiffer struct {
+	ignore    []string
+	unordered bool
+	changes   []JSONChange
+}
+
+// diff compares two decoded JSON values on the given path.
+func (d *jsonDiffer) diff(path string, a, b any) {
+	if d.isIgnored(path) {
+		return
+	}
+
+	switch va := a.(type) {
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(va)+len(vb))
+		for k := range va {
+			keys = append(keys, k)
+		}
+		for k := range vb {
+			if _, exists := va[k]; !exists {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			p := path + "/" + escapeJSONPointer(k)
+
+			xa, inA := va[k]
+			xb, inB := vb[k]
+
+			switch {
+			case !inB:
+				d.add(JSONDiffRemoved, p, xa, nil)
+			case !inA:
+				d.add(JSONDiffAdded, p, nil, xb)
+			default:
+				d.diff(p, xa, xb)
+			}
+		}
+
+		return
+	case []any:
+		vb, ok := b.([]any)
+		if !ok {
+			break
+		}
+
+		if d.unordered {
+			d.diffUnordered(path, va, vb)
+			return
+		}
+
+		for i := 0; i < max(len(va), len(vb)); i++ {
+			p := path + "/" + strconv.Itoa(i)
+
+			switch {
+			case i >= len(vb):
+				d.add(JSONDiffRemoved, p, va[i], nil)
+			case i >= len(va):
+				d.add(JSONDiffAdded, p, nil, vb[i])
+			default:
+				d.diff(p, va[i], vb[i])
+			}
+		}
+
+		return
+	}
+
+	if !d.equal(path, a, b) {
+		d.add(JSONDiffModified, path, a, b)
+	}
+}
+
+// diffUnordered compares two arrays as multisets.
+func (d *jsonDiffer) diffUnordered(path string, a, b []any) {
+	matched := make([]bool, len(b))
+
+	for i := range a {
+		found := false
+		for j := range b {
+			if !matched[j] && d.equal(path+"/"+strconv.Itoa(i), a[i], b[j]) {
+				matched[j], found = true, true
+				break
+			}
+		}
+
+		if !found {
+			d.add(JSONDiffRemoved, path+"/"+strconv.Itoa(i), a[i], nil)
+		}
+	}
+
+	for j := range b {
+		if !matched[j] {
+			d.add(JSONDiffAdded, path+"/"+strconv.Itoa(j), nil, b[j])
+		}
+	}
+}
+
+// add adds the change, if its path is not ignored.
+func (d *jsonDiffer) add(op JSONDiffOp, path string, oldValue, newValue any) {
+	if d.isIgnored(path) {
+		return
+	}
+
+	d.changes = append(d.changes, JSONChange{Op: op, Path: path, OldValue: oldValue, NewValue: newValue})
+}
+
+// isIgnored reports whether the path (or its parent) is in the ignored paths.
+func (d *jsonDiffer) isIgnored(path string) bool {
+	for _, pattern := range d.ignore {
+		if matchJSONPointer(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equal reports whether two decoded JSON values on the given path are deeply
+// equal, except the ignored paths. Numbers are compared by their exact values
+// (for ex., 1 equals 1.0).
+func (d *jsonDiffer) equal(path string, a, b any) bool {
+	if d.isIgnored(path) {
+		return true
+	}
+
+	switch va := a.(type) {
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		for k, x := range va {
+			p := path + "/" + escapeJSONPointer(k)
+			if y, exists := vb[k]; !d.equal(p, x, y) || (!exists && !d.isIgnored(p)) {
+				return false
+			}
+		}
+
+		for k := range vb {
+			if _, exists := va[k]; !exists && !d.isIgnored(path+"/"+escapeJSONPointer(k)) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		vb, ok := b.([]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+
+		if d.unordered {
+			matched := make([]bool, len(vb))
+		next:
+			for i := range va {
+				p := path + "/" + strconv.Itoa(i)
+				for j := range vb {
+					if !matched[j] && d.equal(p, va[i], vb[j]) {
+						matched[j] = true
+						continue next
+					}
+				}
+				return false
+			}
+
+			return true
+		}
+
+		for i := range va {
+			if !d.equal(path+"/"+strconv.Itoa(i), va[i], vb[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		vb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		if va == vb {
+			return true
+		}
+
+		x, okA := new(big.Rat).SetString(va.String())
+		y, okB := new(big.Rat).SetString(vb.String())
+
+		return okA && okB && x.Cmp(y) == 0
+	default:
+		return a == b
+	}
+}
+
+// escapeJSONPointer escapes the key for the JSON Pointer (RFC 6901).
+func escapeJSONPointer(key string) string {
+	if !strings.ContainsAny(key, "~/") {
+		return key
+	}
+
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// matchJSONPointer reports whether the path is matched by the pattern (or
+// nested into the matched path). The "*" segment matches any key or index.
+func matchJSONPointer(pattern, path string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(path, "/")
+
+	if len(pathSegments) < len(patternSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if segment != "*" && segment != pathSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,8 +1,11 @@
 package gosl
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func BenchmarkEquals(b *testing.B) {
@@ -17,6 +20,15 @@ func BenchmarkNotEquals(b *testing.B) {
 	}
 }
 
+func BenchmarkDiffJSON(b *testing.B) {
+	a := []byte(`{"id":1,"name":"Viktor","tags":["admin","user"],"address":{"city":"Moscow"}}`)
+	c := []byte(`{"id":1,"name":"Vic","tags":["user"],"address":{"city":"Berlin"},"email":"my@mail.com"}`)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = DiffJSON(a, c)
+	}
+}
+
 func TestEquals(t *testing.T) {
 	b := Equals("hello", "hello")
 	assert.EqualValues(t, b, true)
@@ -52,3 +64,104 @@ func TestNotEquals(t *testing.T) {
 	b = g2.NotEquals(42, 64)
 	assert.EqualValues(t, b, true)
 }
+
+func TestDiffJSON(t *testing.T) {
+	a := []byte(`{"id":1,"name":"Viktor","price":10,"tags":["admin","user"],"address":{"city":"Moscow","zip":"101000"},"a/b":true}`)
+	b := []byte(`{"id":1,"name":"Vic","price":10.0,"tags":["admin"],"address":{"city":"Berlin","zip":"101000"},"email":"my@mail.com","a/b":false}`)
+
+	expected := []JSONChange{
+		{Op: JSONDiffModified, Path: "/a~1b", OldValue: true, NewValue: false},
+		{Op: JSONDiffModified, Path: "/address/city", OldValue: "Moscow", NewValue: "Berlin"},
+		{Op: JSONDiffAdded, Path: "/email", OldValue: nil, NewValue: "my@mail.com"},
+		{Op: JSONDiffModified, Path: "/name", OldValue: "Viktor", NewValue: "Vic"},
+		{Op: JSONDiffRemoved, Path: "/tags/1", OldValue: "user", NewValue: nil},
+	}
+
+	changes, err := DiffJSON(a, b)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, changes)
+
+	changes, err = DiffJSON(a, a)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = DiffJSON([]byte(`{"id":1}`), []byte(`[1]`))
+	require.NoError(t, err)
+	assert.EqualValues(t, []JSONChange{{Op: JSONDiffModified, Path: "", OldValue: map[string]any{"id": json.Number("1")}, NewValue: []any{json.Number("1")}}}, changes)
+
+	_, err = DiffJSON([]byte(`{`), b)
+	require.Error(t, err)
+
+	_, err = DiffJSON(a, []byte(`{`))
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	changes, err = g.DiffJSON(a, b)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, changes)
+}
+
+func TestDiffJSON_Options(t *testing.T) {
+	a := []byte(`{"items":[{"id":1,"updated_at":"2023"},{"id":2,"updated_at":"2023"}],"tags":["a","b","c"],"meta":{"x":1}}`)
+	b := []byte(`{"items":[{"id":1,"updated_at":"2024"},{"id":2,"updated_at":"2024"}],"tags":["c","a","d"],"meta":{"x":2}}`)
+
+	changes, err := DiffJSON(a, b, DiffOptions{IgnorePaths: []string{"/items/*/updated_at", "/meta"}})
+	require.NoError(t, err)
+	assert.EqualValues(t, []JSONChange{
+		{Op: JSONDiffModified, Path: "/tags/0", OldValue: "a", NewValue: "c"},
+		{Op: JSONDiffModified, Path: "/tags/1", OldValue: "b", NewValue: "a"},
+		{Op: JSONDiffModified, Path: "/tags/2", OldValue: "c", NewValue: "d"},
+	}, changes)
+
+	changes, err = DiffJSON(a, b, DiffOptions{IgnorePaths: []string{"/items/*/updated_at", "/meta"}, UnorderedArrays: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, []JSONChange{
+		{Op: JSONDiffRemoved, Path: "/tags/1", OldValue: "b", NewValue: nil},
+		{Op: JSONDiffAdded, Path: "/tags/2", OldValue: nil, NewValue: "d"},
+	}, changes)
+
+	changes, err = DiffJSON(a, b, DiffOptions{IgnorePaths: []string{""}})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = DiffJSON([]byte(`[[1,2],[3]]`), []byte(`[[3],[2,1]]`), DiffOptions{UnorderedArrays: true})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDiffValues(t *testing.T) {
+	type user struct {
+		ID   int      `json:"id"`
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	a := &user{ID: 1, Name: "Viktor", Tags: []string{"admin"}}
+	b := &user{ID: 2, Name: "Viktor", Tags: []string{"admin", "user"}}
+
+	expected := []JSONChange{
+		{Op: JSONDiffModified, Path: "/id", OldValue: json.Number("1"), NewValue: json.Number("2")},
+		{Op: JSONDiffAdded, Path: "/tags/1", OldValue: nil, NewValue: "user"},
+	}
+
+	changes, err := DiffValues(a, b)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, changes)
+
+	changes, err = DiffValues(a, b, DiffOptions{IgnorePaths: []string{"/id", "/tags"}})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = DiffValues(&map[string]any{"f": func() {}}, &map[string]any{})
+	require.Error(t, err)
+
+	_, err = DiffValues(&map[string]any{}, &map[string]any{"f": func() {}})
+	require.Error(t, err)
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	changes, err = g.DiffValues(a, b)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, changes)
+}
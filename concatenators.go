@@ -1,6 +1,11 @@
 package gosl
 
-import "unsafe"
+import (
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+)
 
 // Concat concatenate strings using the built-in copy and "unsafe" package with
 // unsafe.String function.
@@ -44,5 +49,267 @@ func Concat(s ...string) string {
 		idx += len(s[i])
 	}
 
-	return unsafe.String(unsafe.SliceData(b), n)
+	return bytesToString(b)
+}
+
+// ConcatWithSep concatenate strings with the given separator between them
+// using the built-in copy and "unsafe" package with unsafe.String function.
+//
+// If s has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ConcatWithSep(", ", "one", "two", "three")
+//
+//		fmt.Println(s) // one, two, three
+//	}
+func ConcatWithSep(sep string, s ...string) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	n := len(sep) * (len(s) - 1)
+	for i := 0; i < len(s); i++ {
+		n += len(s[i])
+	}
+
+	if n == 0 {
+		return ""
+	}
+
+	b := make([]byte, n)
+
+	idx := copy(b, s[0])
+	for i := 1; i < len(s); i++ {
+		idx += copy(b[idx:], sep)
+		idx += copy(b[idx:], s[i])
+	}
+
+	return bytesToString(b)
+}
+
+// JoinFunc converts items of type T to strings with the given function and
+// concatenate them with the given separator between them using the built-in
+// copy and "unsafe" package with unsafe.String function.
+//
+// If items has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"strconv"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.JoinFunc([]int{1, 2, 3}, ", ", strconv.Itoa)
+//
+//		fmt.Println(s) // 1, 2, 3
+//	}
+func JoinFunc[T any](items []T, sep string, fn func(T) string) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	// Small number of items are converted on the stack.
+	var stack [16]string
+
+	var parts []string
+	if len(items) <= len(stack) {
+		parts = stack[:len(items)]
+	} else {
+		parts = make([]string, len(items))
+	}
+
+	for i := range items {
+		parts[i] = fn(items[i])
+	}
+
+	return ConcatWithSep(sep, parts...)
+}
+
+// JoinQuoted concatenate double-quoted (with Go escape sequences, like the
+// strconv.Quote function does) strings with the given separator between them
+// using the built-in "strconv" and "unsafe" packages.
+//
+// If s has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.JoinQuoted(", ", "one", "two", "three")
+//
+//		fmt.Println(s) // "one", "two", "three"
+//	}
+func JoinQuoted(sep string, s ...string) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	// Precompute length for strings without escape sequences.
+	n := (len(sep)+2)*len(s) - len(sep)
+	for i := 0; i < len(s); i++ {
+		n += len(s[i])
+	}
+
+	b := make([]byte, 0, n)
+
+	for i := 0; i < len(s); i++ {
+		if i > 0 {
+			b = append(b, sep...)
+		}
+
+		if needsQuoteEscape(s[i]) {
+			b = strconv.AppendQuote(b, s[i])
+		} else {
+			b = append(append(append(b, '"'), s[i]...), '"')
+		}
+	}
+
+	return bytesToString(b)
+}
+
+// needsQuoteEscape reports whether the string has non-printable ASCII or
+// non-ASCII characters, double quotes or backslashes.
+func needsQuoteEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= 0x7f || c == '"' || c == '\\' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConcatBytes concatenate byte slices to the one byte slice using the
+// built-in copy function with a single allocation.
+//
+// If parts has no elements returns zero-value for a byte slice.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		b := gosl.ConcatBytes([]byte("this "), []byte("is "), []byte("my bytes"))
+//
+//		fmt.Println(string(b))
+//	}
+func ConcatBytes(parts ...[]byte) []byte {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	n := 0
+	for i := 0; i < len(parts); i++ {
+		n += len(parts[i])
+	}
+
+	b := make([]byte, n)
+
+	idx := 0
+	for i := 0; i < len(parts); i++ {
+		idx += copy(b[idx:], parts[i])
+	}
+
+	return b
+}
+
+// AppendConcat appends strings to the given byte slice dst and returns the
+// extended byte slice. If dst has not enough capacity, it grows with a single
+// allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		dst := make([]byte, 0, 64)
+//
+//		dst = gosl.AppendConcat(dst, "this ", "is ", "my string")
+//
+//		fmt.Println(string(dst))
+//	}
+func AppendConcat(dst []byte, s ...string) []byte {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n += len(s[i])
+	}
+
+	dst = slices.Grow(dst, n)
+
+	for i := 0; i < len(s); i++ {
+		dst = append(dst, s[i]...)
+	}
+
+	return dst
+}
+
+// WriteConcat concatenate strings and writes them to the given io.Writer with
+// a single allocation and a single call of the Write method. Returns the
+// number of bytes written.
+//
+// If err != nil returns the number of bytes written and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"log"
+//		"os"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		_, err := gosl.WriteConcat(os.Stdout, "this ", "is ", "my string\n")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func WriteConcat(w io.Writer, s ...string) (int, error) {
+	if w == nil {
+		return 0, errors.New("can't write strings to nil writer")
+	}
+
+	b := AppendConcat(nil, s...)
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	return w.Write(b)
 }
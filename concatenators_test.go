@@ -1,6 +1,11 @@
 package gosl
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +13,10 @@ import (
 
 var resultConcatString string
 
+// safeModeAllocs is the number of extra allocations for copying the result in
+// the safe mode (see SafeMode).
+var safeModeAllocs = map[bool]float64{false: 0, true: 1}[SafeMode]
+
 func BenchmarkConcat_String2(b *testing.B) {
 	var r string
 	for i := 0; i < b.N; i++ {
@@ -75,6 +84,104 @@ func BenchmarkConcat_String32(b *testing.B) {
 	resultConcatString = r
 }
 
+var benchJoinStrings = []string{
+	"Lorem ipsum",
+	"dolor sit amet",
+	"consectetur adipiscing elit",
+	"sed do eiusmod",
+	"tempor incididunt",
+	"ut labore et",
+	"dolore magna",
+	"aliqua.",
+}
+
+func BenchmarkConcatWithSep_String8(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = ConcatWithSep(", ", benchJoinStrings...)
+	}
+	resultConcatString = r
+}
+
+func BenchmarkStringsJoin_String8(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = strings.Join(benchJoinStrings, ", ")
+	}
+	resultConcatString = r
+}
+
+func BenchmarkStringsBuilder_String8(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		var sb strings.Builder
+		for j, s := range benchJoinStrings {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(s)
+		}
+		r = sb.String()
+	}
+	resultConcatString = r
+}
+
+func BenchmarkJoinFunc_Int8(b *testing.B) {
+	items := []int{1, 22, 333, 4444, 55555, 666666, 7777777, 88888888}
+
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = JoinFunc(items, ", ", strconv.Itoa)
+	}
+	resultConcatString = r
+}
+
+func BenchmarkJoinQuoted_String8(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = JoinQuoted(", ", benchJoinStrings...)
+	}
+	resultConcatString = r
+}
+
+var resultConcatBytes []byte
+
+func BenchmarkConcatBytes_Bytes8(b *testing.B) {
+	parts := make([][]byte, len(benchJoinStrings))
+	for i, s := range benchJoinStrings {
+		parts[i] = []byte(s)
+	}
+
+	b.ReportAllocs()
+	var r []byte
+	for i := 0; i < b.N; i++ {
+		r = ConcatBytes(parts...)
+	}
+	resultConcatBytes = r
+}
+
+func BenchmarkAppendConcat_String8(b *testing.B) {
+	dst := make([]byte, 0, 256)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst = AppendConcat(dst[:0], benchJoinStrings...)
+	}
+	resultConcatBytes = dst
+}
+
+func BenchmarkWriteConcat_String8(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = WriteConcat(io.Discard, benchJoinStrings...)
+	}
+}
+
 func TestConcat(t *testing.T) {
 	s := Concat()
 	assert.EqualValues(t, s, "", "should be equal")
@@ -92,3 +199,167 @@ func TestConcat(t *testing.T) {
 	assert.EqualValues(t, s, "Lorem ipsum dolor sit amet, consectetur adipiscing elit", "should be equal")
 	assert.NotEqual(t, s, "wrong", "should not be equal")
 }
+
+func TestConcatWithSep(t *testing.T) {
+	s := ConcatWithSep(", ")
+	assert.EqualValues(t, "", s)
+
+	s = ConcatWithSep(", ", "", "")
+	assert.EqualValues(t, ", ", s)
+
+	s = ConcatWithSep("", "", "")
+	assert.EqualValues(t, "", s)
+
+	s = ConcatWithSep(", ", "one")
+	assert.EqualValues(t, "one", s)
+
+	s = ConcatWithSep(", ", "one", "two", "three")
+	assert.EqualValues(t, strings.Join([]string{"one", "two", "three"}, ", "), s)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s = ConcatWithSep(", ", benchJoinStrings...)
+	})
+	assert.EqualValues(t, 1+safeModeAllocs, allocs)
+
+	g := Utility{} // tests for method
+
+	s = g.ConcatWithSep(", ", "one", "two", "three")
+	assert.EqualValues(t, "one, two, three", s)
+}
+
+func TestJoinFunc(t *testing.T) {
+	s := JoinFunc([]int{}, ", ", strconv.Itoa)
+	assert.EqualValues(t, "", s)
+
+	s = JoinFunc([]int{1, 2, 3}, ", ", strconv.Itoa)
+	assert.EqualValues(t, "1, 2, 3", s)
+
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	s = JoinFunc(items, "-", strconv.Itoa)
+	assert.EqualValues(t, "0-1-2-3-4-5-6-7-8-9-10-11-12-13-14-15-16-17-18-19", s)
+
+	// Only the allocation of the result for the small number of items.
+	words := []string{"one", "two", "three"}
+	allocs := testing.AllocsPerRun(100, func() {
+		s = JoinFunc(words, " ", strings.TrimSpace)
+	})
+	assert.EqualValues(t, 1, allocs)
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	s = g.JoinFunc([]int{1, 2, 3}, ", ", strconv.Itoa)
+	assert.EqualValues(t, "1, 2, 3", s)
+}
+
+func TestJoinQuoted(t *testing.T) {
+	s := JoinQuoted(", ")
+	assert.EqualValues(t, "", s)
+
+	s = JoinQuoted(", ", "one", "two", "three")
+	assert.EqualValues(t, `"one", "two", "three"`, s)
+
+	s = JoinQuoted(",", "say \"hi\"", "")
+	assert.EqualValues(t, `"say \"hi\"",""`, s)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s = JoinQuoted(", ", benchJoinStrings...)
+	})
+	assert.EqualValues(t, 1+safeModeAllocs, allocs)
+
+	g := Utility{} // tests for method
+
+	s = g.JoinQuoted(", ", "one", "two", "three")
+	assert.EqualValues(t, `"one", "two", "three"`, s)
+}
+
+func TestConcatBytes(t *testing.T) {
+	b := ConcatBytes()
+	assert.Nil(t, b)
+
+	b = ConcatBytes([]byte("Lorem ipsum "), nil, []byte("dolor"), []byte{})
+	assert.EqualValues(t, []byte("Lorem ipsum dolor"), b)
+
+	parts := [][]byte{[]byte("one"), []byte("two"), []byte("three")}
+	allocs := testing.AllocsPerRun(100, func() {
+		b = ConcatBytes(parts...)
+	})
+	assert.EqualValues(t, 1, allocs)
+
+	g := Utility{} // tests for method
+
+	b = g.ConcatBytes([]byte("Lorem ipsum "), []byte("dolor"))
+	assert.EqualValues(t, []byte("Lorem ipsum dolor"), b)
+}
+
+func TestAppendConcat(t *testing.T) {
+	b := AppendConcat(nil)
+	assert.Empty(t, b)
+
+	b = AppendConcat([]byte("Lorem "), "ipsum ", "dolor")
+	assert.EqualValues(t, []byte("Lorem ipsum dolor"), b)
+
+	// No allocations, if dst has enough capacity.
+	dst := make([]byte, 0, 128)
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = AppendConcat(dst[:0], benchJoinStrings...)
+	})
+	assert.EqualValues(t, 0, allocs)
+
+	// A single allocation to grow dst.
+	if !raceEnabled {
+		allocs = testing.AllocsPerRun(100, func() {
+			b = AppendConcat([]byte(nil), benchJoinStrings...)
+		})
+		assert.EqualValues(t, 1, allocs)
+	}
+
+	g := Utility{} // tests for method
+
+	b = g.AppendConcat([]byte("Lorem "), "ipsum ", "dolor")
+	assert.EqualValues(t, []byte("Lorem ipsum dolor"), b)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func TestWriteConcat(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := WriteConcat(&buf)
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = WriteConcat(&buf, "Lorem ", "ipsum ", "dolor")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 17, n)
+	assert.EqualValues(t, "Lorem ipsum dolor", buf.String())
+
+	_, err = WriteConcat(nil, "Lorem")
+	assert.Error(t, err)
+
+	_, err = WriteConcat(failingWriter{}, "Lorem")
+	assert.Error(t, err)
+
+	if !raceEnabled {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = WriteConcat(io.Discard, benchJoinStrings...)
+		})
+		assert.EqualValues(t, 1, allocs)
+	}
+
+	g := Utility{} // tests for method
+
+	buf.Reset()
+
+	n, err = g.WriteConcat(&buf, "Lorem ", "ipsum")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 11, n)
+	assert.EqualValues(t, "Lorem ipsum", buf.String())
+}
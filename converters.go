@@ -1,12 +1,26 @@
 package gosl
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/base32"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 	"unsafe"
 )
 
 // ToBytes converts string to byte slice using the built-in "unsafe" package
-// with unsafe.Slice function.
+// with unsafe.Slice function. The result aliases memory of the string and must
+// not be modified (build with the "gosl_safe" tag to get a copy, or with the
+// "gosl_debug" tag to detect such writes).
 //
 // If err != nil returns zero-value for a byte slice and error.
 //
@@ -36,11 +50,12 @@ func ToBytes(s string) ([]byte, error) {
 		return nil, errors.New("can't convert empty string to byte slice")
 	}
 
-	return unsafe.Slice(unsafe.StringData(s), len(s)), nil
+	return stringToBytes(s), nil
 }
 
 // ToString converts byte slice to string using the built-in "unsafe" package
-// with unsafe.String function.
+// with unsafe.String function. The byte slice must not be modified after the
+// call (build with the "gosl_safe" tag to get a copy).
 //
 // If err != nil returns zero-value for a string and error.
 //
@@ -70,5 +85,1844 @@ func ToString(b []byte) (string, error) {
 		return "", errors.New("can't convert nil byte slice to string")
 	}
 
-	return unsafe.String(unsafe.SliceData(b), len(b)), nil
+	return bytesToString(b), nil
+}
+
+// ParseNumber parses a number of the given integer or floating-point type T
+// from the string. Supports underscores between digits (for ex., "1_000") and
+// prefixes for the hexadecimal ("0x"), octal ("0o") and binary ("0b") integers,
+// otherwise the string is parsed as a decimal number (for ex., "010" is 10).
+//
+// If the number is out of range for the type T (for ex., "300" for uint8)
+// returns strconv.ErrRange error.
+//
+// If err != nil returns zero-value for the type T and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		port, err := gosl.ParseNumber[uint16]("8_080")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(port) // 8080
+//	}
+func ParseNumber[T Integer | Float](s string) (T, error) {
+	t := reflect.TypeFor[T]()
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil && errors.Is(err, strconv.ErrSyntax) && hasIntegerPrefix(s) {
+			if strings.HasPrefix(s, "-") {
+				var i int64
+				if i, err = strconv.ParseInt(s, 0, 64); err == nil {
+					f = float64(i)
+				}
+			} else {
+				var u uint64
+				if u, err = strconv.ParseUint(strings.TrimPrefix(s, "+"), 0, 64); err == nil {
+					f = float64(u)
+				}
+			}
+		}
+		if err != nil {
+			return 0, numberError(s, t, err)
+		}
+
+		return T(f), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(trimLeadingZeros(strings.TrimPrefix(s, "+")), 0, t.Bits())
+		if err != nil {
+			return 0, numberError(s, t, err)
+		}
+
+		return T(u), nil
+	default:
+		i, err := strconv.ParseInt(trimLeadingZeros(s), 0, t.Bits())
+		if err != nil {
+			return 0, numberError(s, t, err)
+		}
+
+		return T(i), nil
+	}
+}
+
+// ParseBool parses a bool from the given string. Accepts "1", "t", "true",
+// "y", "yes", "on" as true and "0", "f", "false", "n", "no", "off" as false
+// (case-insensitive).
+//
+// If err != nil returns false and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		b, err := gosl.ParseBool("yes")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(b) // true
+//	}
+func ParseBool(s string) (bool, error) {
+	for _, v := range [...]string{"1", "t", "true", "y", "yes", "on"} {
+		if strings.EqualFold(s, v) {
+			return true, nil
+		}
+	}
+
+	for _, v := range [...]string{"0", "f", "false", "n", "no", "off"} {
+		if strings.EqualFold(s, v) {
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("can't parse %q to bool, %w", s, strconv.ErrSyntax)
+}
+
+// FormatNumber formats the given integer or floating-point number with the
+// separator between groups of thousands (for ex., "1,234,567.89"). If sep is
+// empty, uses a comma.
+//
+// Floating-point numbers are formatted with the smallest number of digits
+// necessary to represent the value exactly.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.FormatNumber(1234567.89, " ")
+//
+//		fmt.Println(s) // 1 234 567.89
+//	}
+func FormatNumber[T Integer | Float](v T, sep string) string {
+	if sep == "" {
+		sep = ","
+	}
+
+	var scratch [64]byte
+	var digits []byte
+
+	t := reflect.TypeFor[T]()
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		digits = strconv.AppendFloat(scratch[:0], float64(v), 'f', -1, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		digits = strconv.AppendUint(scratch[:0], uint64(v), 10)
+	default:
+		digits = strconv.AppendInt(scratch[:0], int64(v), 10)
+	}
+
+	// Find the integer part of the number.
+	start := 0
+	if digits[0] == '-' {
+		start = 1
+	}
+
+	end := bytes.IndexByte(digits, '.')
+	if end < 0 {
+		end = len(digits)
+	}
+
+	// Check, if the number has no groups of thousands (or it's NaN or Inf).
+	if end-start <= 3 || digits[start] < '0' || digits[start] > '9' {
+		return string(digits)
+	}
+
+	b := make([]byte, 0, len(digits)+(end-start-1)/3*len(sep))
+	b = append(b, digits[:start]...)
+
+	first := (end - start) % 3
+	if first == 0 {
+		first = 3
+	}
+	b = append(b, digits[start:start+first]...)
+
+	for i := start + first; i < end; i += 3 {
+		b = append(b, sep...)
+		b = append(b, digits[i:i+3]...)
+	}
+
+	b = append(b, digits[end:]...)
+
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// hasIntegerPrefix reports whether the given string has a prefix of the
+// hexadecimal, octal or binary integer.
+func hasIntegerPrefix(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+
+	if len(s) < 2 || s[0] != '0' {
+		return false
+	}
+
+	switch s[1] {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+// trimLeadingZeros trims the leading zeros (and underscores between them) of
+// the decimal number (for ex., "-010" or "-0_10" to "-10"), because the
+// strconv.ParseInt function with zero base parses them as the octal number.
+func trimLeadingZeros(s string) string {
+	sign, digits := "", s
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	if len(digits) < 2 || digits[0] != '0' || (digits[1] != '_' && (digits[1] < '0' || digits[1] > '9')) {
+		return s
+	}
+
+	prefix := digits[:len(digits)-len(strings.TrimLeft(digits, "0_"))]
+	digits = digits[len(prefix):]
+
+	switch {
+	case strings.Contains(prefix, "__"), strings.HasSuffix(prefix, "_") && (digits == "" || digits[0] < '0' || digits[0] > '9'):
+		return s // invalid underscores, leave it to the strconv package
+	case digits == "":
+		return "0"
+	case digits[0] < '0' || digits[0] > '9':
+		return s // invalid number, leave it to the strconv package
+	default:
+		return sign + digits
+	}
+}
+
+// numberError returns the error of the ParseNumber function.
+func numberError(s string, t reflect.Type, err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		err = numErr.Err
+	}
+
+	return fmt.Errorf("can't parse %q to %s, %w", s, t, err)
+}
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+// castTimeLayouts represents a list of layouts for casting strings to the
+// time.Time values.
+var castTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.RubyDate,
+	time.UnixDate,
+	time.ANSIC,
+}
+
+// Cast converts the given value of any type (for ex., from the map[string]any
+// of the ModifyByValue function or the JSON document) to the type T.
+//
+// Supports conversions between all numeric kinds, strings (and json.Number),
+// bools, time.Duration (from strings, like "1h30m", or nanoseconds), time.Time
+// (from strings in the RFC 3339 and other common layouts, or Unix seconds),
+// slices (for ex., []any to []int), maps, structs (from maps, see MapToStruct),
+// pointers and types, implementing the encoding.TextUnmarshaler interface.
+//
+// Lossy conversions return error: numbers out of range for the type T (with
+// the strconv.ErrRange error), floats with a fractional part to integers or
+// integers, which can't be represented exactly as floats.
+//
+// If err != nil returns zero-value for the type T and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		m := map[string]any{"port": 8080.0, "tags": []any{"a", "b"}}
+//
+//		port, err := gosl.Cast[uint16](m["port"])
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		tags, err := gosl.Cast[[]string](m["tags"])
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(port, tags) // 8080 [a b]
+//	}
+func Cast[T any](v any) (T, error) {
+	// Check, if the value already has the type T.
+	if result, ok := v.(T); ok {
+		return result, nil
+	}
+
+	var result T
+	if err := castValue(reflect.ValueOf(&result).Elem(), reflect.ValueOf(v)); err != nil {
+		var zero T
+		return zero, fmt.Errorf("can't cast %T to %s, %w", v, reflect.TypeFor[T](), err)
+	}
+
+	return result, nil
+}
+
+// MustCast converts the given value of any type to the type T (see Cast).
+//
+// If the value can't be converted, MustCast panics.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"time"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		timeout := gosl.MustCast[time.Duration]("1m30s")
+//
+//		fmt.Println(timeout) // 1m30s
+//	}
+func MustCast[T any](v any) T {
+	result, err := Cast[T](v)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// castValue converts the source value to the type of the destination value
+// and sets it.
+func castValue(dst, src reflect.Value) error {
+	// Dereference pointers and interfaces of the source value.
+	for src.IsValid() && !src.Type().AssignableTo(dst.Type()) &&
+		(src.Kind() == reflect.Pointer || src.Kind() == reflect.Interface) {
+		if src.IsNil() {
+			src = reflect.Value{}
+			break
+		}
+		src = src.Elem()
+	}
+
+	if !src.IsValid() {
+		switch dst.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			dst.SetZero()
+			return nil
+		default:
+			return errors.New("value is nil")
+		}
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Type() {
+	case durationType:
+		return castDuration(dst, src)
+	case timeType:
+		return castTime(dst, src)
+	}
+
+	// Check, if the destination type has its own decoding from the string.
+	if src.Kind() == reflect.String && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(src.String()))
+	}
+
+	switch dst.Kind() {
+	case reflect.Bool:
+		b, err := castBool(src)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := castInt(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s, %w", i, dst.Type(), strconv.ErrRange)
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := castUint(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %s, %w", u, dst.Type(), strconv.ErrRange)
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := castFloat(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("value %g overflows %s, %w", f, dst.Type(), strconv.ErrRange)
+		}
+		// Check, if the whole number is rounded by the float32 type.
+		if dst.Type().Bits() == 32 && f == math.Trunc(f) && float64(float32(f)) != f {
+			return fmt.Errorf("value %s can't be represented exactly as %s", strconv.FormatFloat(f, 'f', -1, 64), dst.Type())
+		}
+		dst.SetFloat(f)
+	case reflect.String:
+		s, err := castString(src)
+		if err != nil {
+			return err
+		}
+		dst.SetString(s)
+	case reflect.Slice:
+		return castSlice(dst, src)
+	case reflect.Map:
+		return castMap(dst, src)
+	case reflect.Struct:
+		return castStruct(dst, src)
+	case reflect.Pointer:
+		p := reflect.New(dst.Type().Elem())
+		if err := castValue(p.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(p)
+	default:
+		return fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+
+	return nil
+}
+
+// castBool converts the source value to a bool.
+func castBool(src reflect.Value) (bool, error) {
+	switch src.Kind() {
+	case reflect.Bool:
+		return src.Bool(), nil
+	case reflect.String:
+		return ParseBool(src.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		f, err := castFloat(src)
+		if err != nil || (f != 0 && f != 1) {
+			return false, fmt.Errorf("value %v is not 0 or 1", src)
+		}
+		return f == 1, nil
+	default:
+		return false, fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+}
+
+// castInt converts the source value to an int64.
+func castInt(src reflect.Value) (int64, error) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return src.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if src.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64, %w", src.Uint(), strconv.ErrRange)
+		}
+		return int64(src.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInt(src.Float())
+	case reflect.Bool:
+		if src.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		i, err := ParseNumber[int64](src.String())
+		if err == nil || errors.Is(err, strconv.ErrRange) {
+			return i, err
+		}
+
+		// Try to parse the string as a float without a fractional part (for
+		// ex., "1e3" or "10.0").
+		f, ferr := ParseNumber[float64](src.String())
+		if ferr != nil {
+			return 0, err
+		}
+		return floatToInt(f)
+	default:
+		return 0, fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+}
+
+// castUint converts the source value to an uint64.
+func castUint(src reflect.Value) (uint64, error) {
+	switch src.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return src.Uint(), nil
+	case reflect.String:
+		u, err := ParseNumber[uint64](src.String())
+		if err == nil || errors.Is(err, strconv.ErrRange) {
+			return u, err
+		}
+		fallthrough // negative numbers or floats are checked as int64
+	default:
+		i, err := castInt(src)
+		if err != nil {
+			return 0, err
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("negative value %d overflows uint64, %w", i, strconv.ErrRange)
+		}
+		return uint64(i), nil
+	}
+}
+
+// castFloat converts the source value to a float64.
+func castFloat(src reflect.Value) (float64, error) {
+	switch src.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return src.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := src.Int()
+		if f := float64(i); f >= math.MaxInt64 || int64(f) != i {
+			return 0, fmt.Errorf("value %d can't be represented exactly as float", i)
+		}
+		return float64(i), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := src.Uint()
+		if f := float64(u); f >= math.MaxUint64 || uint64(f) != u {
+			return 0, fmt.Errorf("value %d can't be represented exactly as float", u)
+		}
+		return float64(u), nil
+	case reflect.Bool:
+		if src.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return ParseNumber[float64](src.String())
+	default:
+		return 0, fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+}
+
+// castString converts the source value to a string.
+func castString(src reflect.Value) (string, error) {
+	// Check, if the value has its own string representation (for ex., the
+	// time.Duration or enums).
+	if src.Kind() != reflect.String && src.Type().Implements(stringerType) && src.CanInterface() {
+		if src.Type() == timeType {
+			return src.Interface().(time.Time).Format(time.RFC3339Nano), nil
+		}
+		return src.Interface().(fmt.Stringer).String(), nil
+	}
+
+	switch src.Kind() {
+	case reflect.String:
+		return src.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(src.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(src.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(src.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		// Format floats without the exponent (like JavaScript does), except
+		// for very large and small numbers.
+		f, format := src.Float(), byte('f')
+		if abs := math.Abs(f); abs >= 1e21 || (abs < 1e-6 && abs != 0) {
+			format = 'g'
+		}
+		return strconv.FormatFloat(f, format, -1, src.Type().Bits()), nil
+	case reflect.Slice:
+		if src.Type().Elem().Kind() == reflect.Uint8 {
+			return string(src.Bytes()), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported conversion from %s", src.Type())
+}
+
+// castDuration converts the source value (a string or nanoseconds) to the
+// time.Duration value (see ParseDuration).
+func castDuration(dst, src reflect.Value) error {
+	if src.Kind() == reflect.String && src.Type() != jsonNumberType {
+		d, err := ParseDuration(src.String())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	i, err := castInt(src)
+	if err != nil {
+		return err
+	}
+	dst.SetInt(i)
+
+	return nil
+}
+
+// castTime converts the source value (a string or Unix seconds) to the
+// time.Time value.
+func castTime(dst, src reflect.Value) error {
+	if src.Kind() == reflect.String && src.Type() != jsonNumberType {
+		for _, layout := range castTimeLayouts {
+			if t, err := time.Parse(layout, src.String()); err == nil {
+				dst.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown layout of the time (%s)", src.String())
+	}
+
+	i, err := castInt(src)
+	if err != nil {
+		return err
+	}
+	dst.Set(reflect.ValueOf(time.Unix(i, 0).UTC()))
+
+	return nil
+}
+
+// castSlice converts the source value (a slice, an array or a string for the
+// byte slices) to the slice.
+func castSlice(dst, src reflect.Value) error {
+	switch src.Kind() {
+	case reflect.String:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		dst.Set(reflect.ValueOf([]byte(src.String())).Convert(dst.Type()))
+		return nil
+	case reflect.Slice, reflect.Array:
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := castValue(s.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element #%d, %w", i, err)
+			}
+		}
+		dst.Set(s)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported conversion from %s", src.Type())
+}
+
+// castMap converts the source map to the map.
+func castMap(dst, src reflect.Value) error {
+	if src.Kind() != reflect.Map {
+		return fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+
+	if src.IsNil() {
+		dst.SetZero()
+		return nil
+	}
+
+	m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+	iter := src.MapRange()
+
+	for iter.Next() {
+		key := reflect.New(dst.Type().Key()).Elem()
+		if err := castValue(key, iter.Key()); err != nil {
+			return fmt.Errorf("key %v, %w", iter.Key(), err)
+		}
+
+		value := reflect.New(dst.Type().Elem()).Elem()
+		if err := castValue(value, iter.Value()); err != nil {
+			return fmt.Errorf("value of the key %v, %w", iter.Key(), err)
+		}
+
+		m.SetMapIndex(key, value)
+	}
+	dst.Set(m)
+
+	return nil
+}
+
+// floatToInt converts the float to an int64 without losses.
+func floatToInt(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %g has a fractional part", f)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("value %g overflows int64, %w", f, strconv.ErrRange)
+	}
+
+	return int64(f), nil
+}
+
+// MapOptions represents options for the StructToMap and MapToStruct functions.
+type MapOptions struct {
+	// Flatten sets flat keys of the nested maps, joined by the delimiter (for
+	// ex., "database.host"), like the koanf package does.
+	Flatten bool
+
+	// Delimiter sets a delimiter for the flat keys ("." by default).
+	Delimiter string
+}
+
+// delimiter returns a delimiter for the flat keys.
+func (o MapOptions) delimiter() string {
+	if o.Delimiter == "" {
+		return "."
+	}
+
+	return o.Delimiter
+}
+
+// StructToMap converts the given struct *T to the map[string]any, using names
+// from the given tag (for ex., "koanf" or "json"). Fields without the tag use
+// names of the fields.
+//
+// Supports the "omitempty" tag option, embedded structs (their fields are
+// promoted to the parent map) and nested structs (converted to the nested
+// maps or flat keys with the Flatten option).
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type config struct {
+//		Host     string `koanf:"host"`
+//		Database struct {
+//			DSN string `koanf:"dsn"`
+//		} `koanf:"database"`
+//	}
+//
+//	func main() {
+//		c := &config{Host: "localhost"}
+//		c.Database.DSN = "postgres://localhost"
+//
+//		m, err := gosl.StructToMap(c, "koanf", gosl.MapOptions{Flatten: true})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(m) // map[database.dsn:postgres://localhost host:localhost]
+//	}
+func StructToMap[T any](model *T, tag string, opts ...MapOptions) (map[string]any, error) {
+	if model == nil {
+		return nil, errors.New("can't convert nil struct to map")
+	}
+
+	v := reflect.ValueOf(model).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't convert %s to map, the value must be a struct", v.Type())
+	}
+
+	m := structToMap(v, tag)
+
+	if len(opts) > 0 && opts[0].Flatten {
+		flat := make(map[string]any, len(m))
+		flattenMap(flat, "", m, opts[0].delimiter())
+
+		return flat, nil
+	}
+
+	return m, nil
+}
+
+// MapToStruct converts the given map[string]any to the struct *T. Keys of the
+// map are matched to names from the "koanf" or "json" tags (or names of the
+// fields) in a case-insensitive manner, unknown keys are ignored.
+//
+// Values are converted to the field types with the same rules as the Cast
+// function does. Flat keys (for ex., "database.host") are supported with the
+// Flatten option.
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type config struct {
+//		Host     string `koanf:"host"`
+//		Database struct {
+//			DSN string `koanf:"dsn"`
+//		} `koanf:"database"`
+//	}
+//
+//	func main() {
+//		m := map[string]any{"host": "localhost", "database.dsn": "postgres://localhost"}
+//
+//		c, err := gosl.MapToStruct(m, &config{}, gosl.MapOptions{Flatten: true})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(c.Database.DSN) // postgres://localhost
+//	}
+func MapToStruct[T any](m map[string]any, model *T, opts ...MapOptions) (*T, error) {
+	if model == nil {
+		return nil, errors.New("can't convert map to nil struct")
+	}
+
+	v := reflect.ValueOf(model).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't convert map to %s, the value must be a struct", v.Type())
+	}
+
+	if len(opts) > 0 && opts[0].Flatten {
+		nested, err := unflattenMap(m, opts[0].delimiter())
+		if err != nil {
+			return nil, err
+		}
+		m = nested
+	}
+
+	if err := castStruct(v, reflect.ValueOf(m)); err != nil {
+		return nil, fmt.Errorf("can't convert map to %s, %w", v.Type(), err)
+	}
+
+	return model, nil
+}
+
+// structToMap converts the struct value to the map by names from the tag.
+func structToMap(v reflect.Value, tag string) map[string]any {
+	fields := cachedStructFields(v.Type(), tag)
+	m := make(map[string]any, len(fields))
+
+	for i := range fields {
+		fv, ok := fieldByIndex(v, fields[i].index, false)
+		if !ok || (fields[i].omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		m[fields[i].name] = structValueToAny(fv, tag)
+	}
+
+	return m
+}
+
+// structValueToAny converts the value of the struct field for the map: nested
+// structs are converted to the maps, pointers are dereferenced.
+func structValueToAny(v reflect.Value, tag string) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return structValueToAny(v.Elem(), tag)
+	case reflect.Struct:
+		if isLeafStruct(v.Type()) {
+			return v.Interface()
+		}
+		return structToMap(v, tag)
+	case reflect.Slice, reflect.Array:
+		if (v.Kind() == reflect.Slice && v.IsNil()) || !hasNestedStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = structValueToAny(v.Index(i), tag)
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String || !hasNestedStruct(v.Type().Elem()) {
+			return v.Interface()
+		}
+
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = structValueToAny(iter.Value(), tag)
+		}
+		return m
+	default:
+		return v.Interface()
+	}
+}
+
+// isLeafStruct reports whether the struct type is converted as is (for ex.,
+// time.Time or types with the custom JSON or text marshaling).
+func isLeafStruct(t reflect.Type) bool {
+	return t == timeType ||
+		t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// hasNestedStruct reports whether the type is a struct (or a pointer to the
+// struct), which is converted to the map.
+func hasNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && !isLeafStruct(t)
+}
+
+// flattenMap writes values of the nested maps to the flat map with keys,
+// joined by the delimiter.
+func flattenMap(dst map[string]any, prefix string, m map[string]any, delimiter string) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + delimiter + k
+		}
+
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenMap(dst, key, nested, delimiter)
+			continue
+		}
+
+		dst[key] = v
+	}
+}
+
+// unflattenMap converts the map with flat keys, joined by the delimiter, to
+// the nested maps.
+func unflattenMap(m map[string]any, delimiter string) (map[string]any, error) {
+	nested := make(map[string]any, len(m))
+
+	for k, v := range m {
+		parts := strings.Split(k, delimiter)
+		current := nested
+
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]any)
+			if !ok {
+				if _, exists := current[part]; exists {
+					return nil, fmt.Errorf("error: key (%s) conflicts with the nested key (%s)", part, k)
+				}
+
+				next = map[string]any{}
+				current[part] = next
+			}
+			current = next
+		}
+
+		last := parts[len(parts)-1]
+		if err := mergeNestedValue(current, last, v, k); err != nil {
+			return nil, err
+		}
+	}
+
+	return nested, nil
+}
+
+// mergeNestedValue helps to set the value by the key of the nested map for the
+// unflattenMap function. Nested maps of the value are copied (the source map
+// is never changed) and merged with the existing ones.
+func mergeNestedValue(dst map[string]any, key string, v any, path string) error {
+	src, isMap := v.(map[string]any)
+
+	existing, exists := dst[key]
+	if !exists {
+		if !isMap {
+			dst[key] = v
+			return nil
+		}
+
+		existing = make(map[string]any, len(src))
+		dst[key] = existing
+	}
+
+	next, ok := existing.(map[string]any)
+	if !ok || !isMap {
+		return fmt.Errorf("error: key (%s) conflicts with the nested key", path)
+	}
+
+	for k, v := range src {
+		if err := mergeNestedValue(next, k, v, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// castStruct sets fields of the struct from the source map with string keys.
+// Keys are matched to names from the "koanf" or "json" tags.
+func castStruct(dst, src reflect.Value) error {
+	if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported conversion from %s", src.Type())
+	}
+
+	koanfFields := cachedStructFields(dst.Type(), "koanf")
+	jsonFields := cachedStructFields(dst.Type(), "json")
+
+	iter := src.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+
+		field := findStructField(koanfFields, key)
+		if field == nil {
+			if field = findStructField(jsonFields, key); field == nil {
+				continue // skip unknown key
+			}
+		}
+
+		fv, ok := fieldByIndex(dst, field.index, true)
+		if !ok {
+			return fmt.Errorf("field %s, can't set embedded pointer to unexported struct", key)
+		}
+
+		// Set zero-value for the null value (like the JSON document).
+		if value := iter.Value(); value.Kind() == reflect.Interface && value.IsNil() {
+			fv.SetZero()
+			continue
+		}
+
+		if err := castValue(fv, iter.Value()); err != nil {
+			return fmt.Errorf("field %s, %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ByteSize represents a size in bytes, which can be decoded from the
+// human-readable strings (for ex., "512MiB" or "1.5GB") in the JSON documents
+// and config files (see ParseByteSize).
+type ByteSize uint64
+
+// Units of the byte sizes in the SI (powers of 1000) and IEC (powers of 1024)
+// standards.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1024 * Byte
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+	PiB ByteSize = 1024 * TiB
+	EiB ByteSize = 1024 * PiB
+)
+
+// byteSizeUnits represents a list of the byte size units for parsing and
+// formatting (from the largest one).
+var byteSizeUnits = []struct {
+	name string
+	size ByteSize
+	iec  bool
+}{
+	{"EiB", EiB, true}, {"PiB", PiB, true}, {"TiB", TiB, true}, {"GiB", GiB, true}, {"MiB", MiB, true}, {"KiB", KiB, true},
+	{"EB", EB, false}, {"PB", PB, false}, {"TB", TB, false}, {"GB", GB, false}, {"MB", MB, false}, {"KB", KB, false},
+}
+
+// ParseByteSize parses a size in bytes from the given human-readable string
+// (for ex., "512MiB", "1.5 GB" or "1024"). Supports units in the SI standard
+// ("KB", "MB", "GB", "TB", "PB", "EB", as powers of 1000) and in the IEC
+// standard ("KiB", "MiB", "GiB", "TiB", "PiB", "EiB", as powers of 1024) in a
+// case-insensitive manner. The "B" suffix is optional (for ex., "512Mi" or
+// "1k"). A number without the unit is a size in bytes.
+//
+// Fractional sizes are rounded to the nearest byte. If the size overflows
+// uint64 returns strconv.ErrRange error.
+//
+// If err != nil returns zero-value for a ByteSize and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		size, err := gosl.ParseByteSize("512MiB")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(uint64(size)) // 536870912
+//	}
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+
+	// Split the string to the number and unit.
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+
+	number, unit := s[:i], strings.TrimSpace(s[i:])
+	if number == "" || number == "." || strings.Count(number, ".") > 1 {
+		return 0, fmt.Errorf("can't parse %q to byte size, %w", s, strconv.ErrSyntax)
+	}
+
+	size := Byte
+	if unit = strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "b"); unit != "" {
+		size = 0
+		for _, u := range byteSizeUnits {
+			// Compare the unit with the optional "B" suffix (for ex., "Mi" or
+			// "M").
+			if strings.EqualFold(unit, u.name[:len(u.name)-1]) {
+				size = u.size
+				break
+			}
+		}
+
+		if size == 0 {
+			return 0, fmt.Errorf("can't parse %q to byte size, unknown unit %q", s, s[i:])
+		}
+	}
+
+	// Calculate the exact size and round it to the nearest byte.
+	r, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("can't parse %q to byte size, %w", s, strconv.ErrSyntax)
+	}
+
+	r.Mul(r, new(big.Rat).SetUint64(uint64(size)))
+
+	// Round half up: (2 * num + denom) / (2 * denom).
+	n := new(big.Int).Lsh(r.Num(), 1)
+	n.Add(n, r.Denom())
+	n.Quo(n, new(big.Int).Lsh(r.Denom(), 1))
+
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("can't parse %q to byte size, %w", s, strconv.ErrRange)
+	}
+
+	return ByteSize(n.Uint64()), nil
+}
+
+// FormatByteSize formats the given size in bytes to the human-readable string
+// with the largest unit and up to two decimal places (for ex., "1.5GiB"). If
+// iec is true, uses units in the IEC standard (powers of 1024), otherwise in
+// the SI standard (powers of 1000).
+//
+// Sizes are rounded half up, except for the sizes near the maximum, which are
+// truncated, so the result can always be parsed by the ParseByteSize function.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		fmt.Println(gosl.FormatByteSize(1536*gosl.MiB, true))  // 1.5GiB
+//		fmt.Println(gosl.FormatByteSize(1536*gosl.MiB, false)) // 1.61GB
+//	}
+func FormatByteSize(size ByteSize, iec bool) string {
+	for _, u := range byteSizeUnits {
+		if u.iec != iec || size < u.size {
+			continue
+		}
+
+		// Calculate the size in hundredths of the unit with integers (floats
+		// lose precision for the large sizes) and round it half up.
+		whole, rem := uint64(size/u.size), uint64(size%u.size)
+		hi, lo := bits.Mul64(rem, 100)
+		cents, r := bits.Div64(hi, lo, uint64(u.size))
+
+		v := whole*100 + cents
+		if 2*r >= uint64(u.size) && !byteSizeOverflows(v+1, uint64(u.size)) {
+			v++ // otherwise truncate, so ParseByteSize can parse the result
+		}
+
+		s := strconv.FormatUint(v/100, 10)
+		if v%100 != 0 {
+			s += strings.TrimRight(fmt.Sprintf(".%02d", v%100), "0")
+		}
+
+		return s + u.name
+	}
+
+	return strconv.FormatUint(uint64(size), 10) + "B"
+}
+
+// byteSizeOverflows reports whether the given number of hundredths of the unit
+// overflows the ByteSize, when it's parsed by the ParseByteSize function.
+func byteSizeOverflows(hundredths, unit uint64) bool {
+	hi, lo := bits.Mul64(hundredths, unit)
+	if hi >= 100 {
+		return true
+	}
+
+	q, r := bits.Div64(hi, lo, 100)
+
+	return q == math.MaxUint64 && 2*r >= 100
+}
+
+// String returns the human-readable size in the IEC units (for ex.,
+// "1.5GiB").
+func (b ByteSize) String() string {
+	return FormatByteSize(b, true)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. Returns the
+// exact size with the largest unit (for ex., "512MiB" or "1500B").
+func (b ByteSize) MarshalText() ([]byte, error) {
+	for _, iec := range []bool{true, false} {
+		if (iec && b < KiB) || (!iec && b < KB) {
+			continue // size in bytes
+		}
+
+		s := FormatByteSize(b, iec)
+		if size, err := ParseByteSize(s); err == nil && size == b {
+			return []byte(s), nil
+		}
+	}
+
+	return append(strconv.AppendUint(nil, uint64(b), 10), 'B'), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface (for ex.,
+// for the config files, parsed by the ParseFileToStruct function).
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = size
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Accepts strings
+// (for ex., "512MiB") and numbers (size in bytes).
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		return b.UnmarshalText([]byte(s))
+	}
+
+	return b.UnmarshalText(data)
+}
+
+// Duration represents a duration, which can be decoded from the human-readable
+// strings with days and weeks (for ex., "2d4h" or "1w") in the JSON documents
+// and config files (see ParseDuration).
+type Duration time.Duration
+
+// ParseDuration parses a duration from the given string like the
+// time.ParseDuration function does, but supports days ("d", 24 hours) and
+// weeks ("w", 7 days) units too (for ex., "2d4h", "1.5w" or "-1d12h30m").
+//
+// If err != nil returns zero-value for a time.Duration and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		d, err := gosl.ParseDuration("2d4h")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(d) // 52h0m0s
+//	}
+func ParseDuration(s string) (time.Duration, error) {
+	// Check, if the string has no days and weeks units.
+	if !strings.ContainsAny(s, "dw") {
+		return time.ParseDuration(s)
+	}
+
+	orig := s
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var total time.Duration
+
+	for s != "" {
+		// Find the number and the unit of the segment.
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+
+		j := i
+		for j < len(s) && (s[j] < '0' || s[j] > '9') && s[j] != '.' {
+			j++
+		}
+
+		number, unit := s[:i], s[i:j]
+		if number == "" {
+			return 0, fmt.Errorf("can't parse %q to duration, %w", orig, strconv.ErrSyntax)
+		}
+
+		var d time.Duration
+		var err error
+
+		switch unit {
+		case "d", "w":
+			d, err = time.ParseDuration(number + "h")
+			if err == nil {
+				hours := time.Duration(24)
+				if unit == "w" {
+					hours *= 7
+				}
+				if d > math.MaxInt64/hours {
+					return 0, fmt.Errorf("can't parse %q to duration, %w", orig, strconv.ErrRange)
+				}
+				d *= hours
+			}
+		default:
+			d, err = time.ParseDuration(s[:j])
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("can't parse %q to duration, %w", orig, strconv.ErrSyntax)
+		}
+
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("can't parse %q to duration, %w", orig, strconv.ErrRange)
+		}
+		total += d
+
+		s = s[j:]
+	}
+
+	if neg {
+		return -total, nil
+	}
+
+	return total, nil
+}
+
+// String returns the duration like the time.Duration does (for ex.,
+// "52h0m0s").
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface (for ex.,
+// for the config files, parsed by the ParseFileToStruct function).
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Accepts strings
+// (for ex., "2d4h") and numbers (duration in nanoseconds).
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		return d.UnmarshalText([]byte(s))
+	}
+
+	n, err := ParseNumber[int64](string(data))
+	if err != nil {
+		return fmt.Errorf("can't parse %s to duration, %w", data, err)
+	}
+
+	*d = Duration(n)
+
+	return nil
+}
+
+// Alphabets of the encodings.
+const (
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// crockfordEncoding represents the Crockford's Base32 encoding without
+// padding.
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+// EncodeBase58 encodes the given byte slice to the Base58 string with the
+// Bitcoin alphabet. Leading zero bytes are encoded as "1" characters.
+//
+// If b has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.EncodeBase58([]byte("Hello World!"))
+//
+//		fmt.Println(s) // 2NEpo7TZRRrLZSi2U
+//	}
+func EncodeBase58(b []byte) string {
+	return encodeBaseN(b, base58Alphabet)
+}
+
+// DecodeBase58 decodes the given Base58 string with the Bitcoin alphabet to
+// the byte slice.
+//
+// If err != nil returns nil and error (for ex., for invalid characters).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		b, err := gosl.DecodeBase58("2NEpo7TZRRrLZSi2U")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(string(b)) // Hello World!
+//	}
+func DecodeBase58(s string) ([]byte, error) {
+	return decodeBaseN(s, base58Alphabet, "base58")
+}
+
+// EncodeBase62 encodes the given byte slice (as a big-endian integer) to the
+// Base62 string with the "0-9A-Za-z" alphabet. Leading zero bytes are encoded
+// as "0" characters.
+//
+// If b has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.EncodeBase62([]byte{0xff, 0xff})
+//
+//		fmt.Println(s) // H31
+//	}
+func EncodeBase62(b []byte) string {
+	return encodeBaseN(b, base62Alphabet)
+}
+
+// DecodeBase62 decodes the given Base62 string with the "0-9A-Za-z" alphabet
+// to the byte slice.
+//
+// If err != nil returns nil and error (for ex., for invalid characters).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		b, err := gosl.DecodeBase62("H31")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(b) // [255 255]
+//	}
+func DecodeBase62(s string) ([]byte, error) {
+	return decodeBaseN(s, base62Alphabet, "base62")
+}
+
+// EncodeUint64Base62 encodes the given number to the Base62 string with the
+// "0-9A-Za-z" alphabet (for ex., for the short links).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.EncodeUint64Base62(1234567890)
+//
+//		fmt.Println(s) // 1LY7VK
+//	}
+func EncodeUint64Base62(n uint64) string {
+	var buf [11]byte // max length of the uint64 in Base62
+
+	i := len(buf)
+	for {
+		i--
+		buf[i] = base62Alphabet[n%62]
+		n /= 62
+
+		if n == 0 {
+			break
+		}
+	}
+
+	return string(buf[i:])
+}
+
+// DecodeUint64Base62 decodes the given Base62 string with the "0-9A-Za-z"
+// alphabet to the number.
+//
+// If the number overflows uint64 returns strconv.ErrRange error.
+//
+// If err != nil returns zero-value for an uint64 and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		n, err := gosl.DecodeUint64Base62("1LY7VK")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(n) // 1234567890
+//	}
+func DecodeUint64Base62(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("can't decode empty base62 string")
+	}
+
+	var n uint64
+
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base62Alphabet, s[i])
+		if d < 0 {
+			return 0, fmt.Errorf("can't decode base62 string, invalid character %q at position %d", s[i], i)
+		}
+
+		if n > (math.MaxUint64-uint64(d))/62 {
+			return 0, fmt.Errorf("can't decode base62 string %q, %w", s, strconv.ErrRange)
+		}
+		n = n*62 + uint64(d)
+	}
+
+	return n, nil
+}
+
+// EncodeBase32Crockford encodes the given byte slice to the Crockford's Base32
+// string (without padding).
+//
+// If b has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.EncodeBase32Crockford([]byte("foobar"))
+//
+//		fmt.Println(s) // CSQPYRK1E8
+//	}
+func EncodeBase32Crockford(b []byte) string {
+	return crockfordEncoding.EncodeToString(b)
+}
+
+// DecodeBase32Crockford decodes the given Crockford's Base32 string to the
+// byte slice. Decoding is case-insensitive, "I" and "L" are decoded as "1",
+// "O" as "0", hyphens are ignored.
+//
+// If err != nil returns nil and error (for ex., for invalid characters or
+// non-canonical strings with non-zero trailing bits).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		b, err := gosl.DecodeBase32Crockford("csqp-yrk1-e8")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(string(b)) // foobar
+//	}
+func DecodeBase32Crockford(s string) ([]byte, error) {
+	normalized := make([]byte, 0, len(s))
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '-':
+			continue
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+
+		switch c {
+		case 'I', 'L':
+			c = '1'
+		case 'O':
+			c = '0'
+		}
+
+		if strings.IndexByte(crockfordAlphabet, c) < 0 {
+			return nil, fmt.Errorf("can't decode base32 string, invalid character %q at position %d", s[i], i)
+		}
+
+		normalized = append(normalized, c)
+	}
+
+	if !isValidBase32Len(len(normalized)) {
+		return nil, fmt.Errorf("can't decode base32 string, invalid length %d", len(normalized))
+	}
+
+	b := make([]byte, crockfordEncoding.DecodedLen(len(normalized)))
+
+	n, err := crockfordEncoding.Decode(b, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode base32 string, %w", err)
+	}
+
+	// Check, if unused trailing bits of the last character are zero, otherwise
+	// the same bytes have several encodings (for ex., "0Z" and "0R").
+	if unused := len(normalized)*5 - n*8; unused > 0 {
+		last := strings.IndexByte(crockfordAlphabet, normalized[len(normalized)-1])
+		if last&(1<<unused-1) != 0 {
+			return nil, fmt.Errorf("can't decode base32 string, non-zero trailing bits at position %d", len(normalized)-1)
+		}
+	}
+
+	return b[:n], nil
+}
+
+// isValidBase32Len reports whether the given length of the Base32 string
+// without padding is valid (the "encoding/base32" package accepts some
+// truncated strings without padding).
+func isValidBase32Len(n int) bool {
+	switch n % 8 {
+	case 1, 3, 6:
+		return false
+	default:
+		return true
+	}
+}
+
+// encodeBaseN encodes the given byte slice (as a big-endian integer) to the
+// string with the alphabet of the Base58 or Base62 encodings.
+func encodeBaseN(b []byte, alphabet string) string {
+	base := uint32(len(alphabet))
+
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	// Convert the number to the digits of the base. The size is estimated as
+	// len(b) * log(256) / log(base), which is less than 1.38 for both bases.
+	size := (len(b)-zeros)*138/100 + 1
+	digits := make([]byte, size)
+	high := size - 1
+
+	for _, c := range b[zeros:] {
+		carry := uint32(c)
+
+		j := size - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 256 * uint32(digits[j])
+			digits[j] = byte(carry % base)
+			carry /= base
+		}
+		high = j
+	}
+
+	start := 0
+	for start < size && digits[start] == 0 {
+		start++
+	}
+
+	out := make([]byte, zeros+size-start)
+	for i := 0; i < zeros; i++ {
+		out[i] = alphabet[0]
+	}
+
+	for i, d := range digits[start:] {
+		out[zeros+i] = alphabet[d]
+	}
+
+	return unsafe.String(unsafe.SliceData(out), len(out))
+}
+
+// decodeBaseN decodes the given string with the alphabet of the Base58 or
+// Base62 encodings to the byte slice (as a big-endian integer).
+func decodeBaseN(s, alphabet, name string) ([]byte, error) {
+	base := uint32(len(alphabet))
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	// Convert the digits of the base to the bytes. The size is estimated as
+	// len(s) * log(base) / log(256), which is less than 0.745 for both bases.
+	size := (len(s)-zeros)*745/1000 + 1
+	b := make([]byte, size)
+	high := size - 1
+
+	for i := zeros; i < len(s); i++ {
+		d := strings.IndexByte(alphabet, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("can't decode %s string, invalid character %q at position %d", name, s[i], i)
+		}
+
+		carry := uint32(d)
+
+		j := size - 1
+		for ; j > high || carry != 0; j-- {
+			carry += base * uint32(b[j])
+			b[j] = byte(carry)
+			carry >>= 8
+		}
+		high = j
+	}
+
+	start := 0
+	for start < size && b[start] == 0 {
+		start++
+	}
+
+	out := make([]byte, zeros+size-start)
+	copy(out[zeros:], b[start:])
+
+	return out, nil
 }
@@ -0,0 +1,51 @@
+//go:build gosl_debug
+
+package gosl
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// SafeMode reports whether the package was built with the "gosl_safe" (or
+// "gosl_debug") build tag. In the safe mode, the ToBytes, ToString and Concat
+// functions copy data instead of aliasing the memory with the "unsafe" package.
+const SafeMode = true
+
+// unsafeWriteHandler is called, when a write to the byte slice returned by the
+// ToBytes function is detected (can be replaced in tests).
+var unsafeWriteHandler = func(msg string) {
+	panic(msg)
+}
+
+// stringToBytes returns a copy of the given string as the byte slice and
+// checks on the garbage collection, if the copy was modified. In the default
+// build, such writes silently corrupt the immutable memory of the string.
+func stringToBytes(s string) []byte {
+	if s == "" {
+		return []byte{}
+	}
+
+	// Allocate at least 16 bytes to avoid the tiny allocator, which batches
+	// small objects (and delays their finalizers).
+	b := make([]byte, len(s), max(len(s), 16))
+	copy(b, s)
+
+	runtime.SetFinalizer(&b[0], func(p *byte) {
+		if got := unsafe.String(p, len(s)); got != s {
+			unsafeWriteHandler(fmt.Sprintf(
+				"gosl: detected write to the byte slice returned by ToBytes (%q changed to %q), "+
+					"this corrupts the string memory in the default build, copy the slice before writing",
+				s, got,
+			))
+		}
+	})
+
+	return b
+}
+
+// bytesToString returns a copy of the given byte slice as the string.
+func bytesToString(b []byte) string {
+	return string(b)
+}
@@ -0,0 +1,52 @@
+//go:build gosl_debug
+
+package gosl
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToBytes_DebugMode(t *testing.T) {
+	detected := make(chan string, 1)
+
+	handler := unsafeWriteHandler
+	unsafeWriteHandler = func(msg string) {
+		select {
+		case detected <- msg:
+		default:
+		}
+	}
+	defer func() { unsafeWriteHandler = handler }()
+
+	s := "hello, world"
+
+	func() {
+		b, err := ToBytes(s)
+		require.NoError(t, err)
+
+		b[0] = 'H' // write to the result
+	}()
+
+	// The string itself must be untouched.
+	assert.Equal(t, "hello, world", s)
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+
+		select {
+		case msg := <-detected:
+			assert.Contains(t, msg, "detected write to the byte slice returned by ToBytes")
+			assert.Contains(t, msg, `"Hello, world"`)
+			return
+		case <-deadline:
+			t.Fatal("write to the byte slice returned by ToBytes was not detected")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,18 @@
+//go:build gosl_safe && !gosl_debug
+
+package gosl
+
+// SafeMode reports whether the package was built with the "gosl_safe" (or
+// "gosl_debug") build tag. In the safe mode, the ToBytes, ToString and Concat
+// functions copy data instead of aliasing the memory with the "unsafe" package.
+const SafeMode = true
+
+// stringToBytes returns a copy of the given string as the byte slice.
+func stringToBytes(s string) []byte {
+	return []byte(s)
+}
+
+// bytesToString returns a copy of the given byte slice as the string.
+func bytesToString(b []byte) string {
+	return string(b)
+}
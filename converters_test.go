@@ -1,7 +1,18 @@
 package gosl
 
 import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,3 +76,1042 @@ func TestToBytes(t *testing.T) {
 	assert.EqualValues(t, b, []byte(`hello, world`), "should be equal")
 	assert.NotEqual(t, b, []byte(`wrong`), "should not be equal")
 }
+
+func BenchmarkParseNumber_Int(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseNumber[int]("1_234_567")
+	}
+}
+
+func BenchmarkFormatNumber_Int(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = FormatNumber(1234567, ",")
+	}
+	resultConvertersString = r
+}
+
+func TestParseNumber(t *testing.T) {
+	i, err := ParseNumber[int]("1_234_567")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1234567, i)
+
+	for s, expected := range map[string]int64{
+		"0":         0,
+		"-0":        0,
+		"+42":       42,
+		"-42":       -42,
+		"010":       10,
+		"-007":      -7,
+		"000":       0,
+		"0_10":      10,
+		"-0_10":     -10,
+		"+00_0_7":   7,
+		"0_0":       0,
+		"0x1F":      31,
+		"-0x_1f":    -31,
+		"0o17":      15,
+		"0b1010":    10,
+		"0B_1_0":    2,
+		"1_000_000": 1000000,
+	} {
+		v, err := ParseNumber[int64](s)
+		require.NoError(t, err, "string %s", s)
+		assert.EqualValues(t, expected, v, "string %s", s)
+	}
+
+	for _, s := range []string{"", " 1", "1.5", "1__0", "_1", "1_", "00x1", "0x", "abc", "0__10", "0_", "0_x1", "9223372036854775808"} {
+		_, err := ParseNumber[int64](s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	// Overflow detection for the target width.
+	i8, err := ParseNumber[int8]("-128")
+	require.NoError(t, err)
+	assert.EqualValues(t, -128, i8)
+
+	_, err = ParseNumber[int8]("128")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	u8, err := ParseNumber[uint8]("0xff")
+	require.NoError(t, err)
+	assert.EqualValues(t, 255, u8)
+
+	_, err = ParseNumber[uint8]("256")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = ParseNumber[uint]("-1")
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+
+	u, err := ParseNumber[uint32]("+4_294_967_295")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4294967295, u)
+
+	_, err = ParseNumber[uint32]("4294967296")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	// Named types.
+	type port uint16
+
+	p, err := ParseNumber[port]("8_080")
+	require.NoError(t, err)
+	assert.EqualValues(t, 8080, p)
+
+	_, err = ParseNumber[port]("65536")
+	require.EqualError(t, err, `can't parse "65536" to gosl.port, value out of range`)
+
+	// Floating-point numbers.
+	for s, expected := range map[string]float64{
+		"1.5":                 1.5,
+		"-1_000.25":           -1000.25,
+		"1e3":                 1000,
+		"010.5":               10.5,
+		"0x10":                16,
+		"-0b101":              -5,
+		"0xffffffffffffffff":  math.MaxUint64,
+		"+0xffffffffffffffff": math.MaxUint64,
+		"-0x8000000000000000": math.MinInt64,
+		"0x1p-2":              0.25,
+		"Inf":                 math.Inf(1),
+		"1_000_000.0":         1000000,
+	} {
+		v, err := ParseNumber[float64](s)
+		require.NoError(t, err, "string %s", s)
+		assert.EqualValues(t, expected, v, "string %s", s)
+	}
+
+	_, err = ParseNumber[float64]("0x1_0000_0000_0000_0000")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = ParseNumber[float64]("1.5.5")
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = ParseNumber[float64]("1e400")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = ParseNumber[float32]("1e39")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	f32, err := ParseNumber[float32]("0.1")
+	require.NoError(t, err)
+	assert.EqualValues(t, float32(0.1), f32)
+}
+
+func TestParseBool(t *testing.T) {
+	for _, s := range []string{"1", "t", "T", "true", "TRUE", "True", "y", "yes", "YES", "on", "On"} {
+		b, err := ParseBool(s)
+		require.NoError(t, err, "string %s", s)
+		assert.True(t, b, "string %s", s)
+	}
+
+	for _, s := range []string{"0", "f", "F", "false", "FALSE", "n", "no", "No", "off", "OFF"} {
+		b, err := ParseBool(s)
+		require.NoError(t, err, "string %s", s)
+		assert.False(t, b, "string %s", s)
+	}
+
+	for _, s := range []string{"", "2", "yep", "enabled", " true"} {
+		_, err := ParseBool(s)
+		require.ErrorIs(t, err, strconv.ErrSyntax, "string %s", s)
+	}
+
+	g := Utility{} // tests for method
+
+	b, err := g.ParseBool("on")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = g.ParseBool("unknown")
+	require.Error(t, err)
+}
+
+func TestFormatNumber(t *testing.T) {
+	assert.Equal(t, "0", FormatNumber(0, ","))
+	assert.Equal(t, "999", FormatNumber(999, ","))
+	assert.Equal(t, "-999", FormatNumber(-999, ","))
+	assert.Equal(t, "1,000", FormatNumber(1000, ""))
+	assert.Equal(t, "-1,234,567", FormatNumber(-1234567, ","))
+	assert.Equal(t, "123 456", FormatNumber(int32(123456), " "))
+	assert.Equal(t, "18_446_744_073_709_551_615", FormatNumber(uint64(math.MaxUint64), "_"))
+	assert.Equal(t, "-9,223,372,036,854,775,808", FormatNumber(int64(math.MinInt64), ","))
+	assert.Equal(t, "1 234 567.89", FormatNumber(1234567.89, " "))
+	assert.Equal(t, "-1'000.5", FormatNumber(float32(-1000.5), "'"))
+	assert.Equal(t, "0.001", FormatNumber(0.001, ","))
+	assert.Equal(t, "NaN", FormatNumber(math.NaN(), ","))
+	assert.Equal(t, "-Inf", FormatNumber(math.Inf(-1), ","))
+
+	s := ""
+	allocs := testing.AllocsPerRun(100, func() {
+		s = FormatNumber(1234567, ",")
+	})
+	assert.EqualValues(t, 1, allocs)
+	assert.Equal(t, "1,234,567", s)
+}
+
+func BenchmarkCast_Float64ToInt(b *testing.B) {
+	var v any = 8080.0
+	for i := 0; i < b.N; i++ {
+		_, _ = Cast[int](v)
+	}
+}
+
+func TestCast(t *testing.T) {
+	type level int8
+	type name string
+
+	// Numbers.
+	for _, v := range []any{8080, int64(8080), uint16(8080), 8080.0, float32(8080), "8080", "8_080", "8.08e3", "0x1F90", json.Number("8080")} {
+		i, err := Cast[int](v)
+		require.NoError(t, err, "value %v (%T)", v, v)
+		assert.Equal(t, 8080, i, "value %v (%T)", v, v)
+	}
+
+	i, err := Cast[int](true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	l, err := Cast[level](json.Number("-100"))
+	require.NoError(t, err)
+	assert.EqualValues(t, -100, l)
+
+	u8, err := Cast[uint8](255.0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 255, u8)
+
+	f, err := Cast[float64](json.Number("1.5"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1.5, f)
+
+	f32, err := Cast[float32](42)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, f32)
+
+	f32, err = Cast[float32](1 << 24) // exactly represented
+	require.NoError(t, err)
+	assert.EqualValues(t, 1<<24, f32)
+
+	f32, err = Cast[float32](0.1) // fractions are rounded to the precision
+	require.NoError(t, err)
+	assert.EqualValues(t, float32(0.1), f32)
+
+	u64, err := Cast[uint64]("18446744073709551615")
+	require.NoError(t, err)
+	assert.EqualValues(t, uint64(math.MaxUint64), u64)
+
+	// Lossy conversions.
+	for _, tc := range []struct {
+		cast  func() error
+		isErr error
+	}{
+		{func() error { _, err := Cast[int](1.5); return err }, nil},
+		{func() error { _, err := Cast[int]("1.5"); return err }, nil},
+		{func() error { _, err := Cast[int](math.NaN()); return err }, nil},
+		{func() error { _, err := Cast[int](math.Inf(1)); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[int8](128); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[int8]("-129"); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[uint8](-1); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[uint]("-1"); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[uint](-1.5); return err }, nil},
+		{func() error { _, err := Cast[int64](uint64(math.MaxUint64)); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[float32](1e39); return err }, strconv.ErrRange},
+		{func() error { _, err := Cast[float32](1<<24 + 1); return err }, nil},
+		{func() error { _, err := Cast[float32]("16777217"); return err }, nil},
+		{func() error { _, err := Cast[float32](16777217.0); return err }, nil},
+		{func() error { _, err := Cast[float64](int64(math.MaxInt64)); return err }, nil},
+		{func() error { _, err := Cast[float64](uint64(1<<53 + 1)); return err }, nil},
+		{func() error { _, err := Cast[int]("abc"); return err }, strconv.ErrSyntax},
+		{func() error { _, err := Cast[int](nil); return err }, nil},
+		{func() error { _, err := Cast[int]([]int{1}); return err }, nil},
+		{func() error { _, err := Cast[bool](2); return err }, nil},
+		{func() error { _, err := Cast[bool]("maybe"); return err }, strconv.ErrSyntax},
+		{func() error { _, err := Cast[string]([]int{1}); return err }, nil},
+		{func() error { _, err := Cast[struct{}]([]any{1}); return err }, nil},
+	} {
+		err := tc.cast()
+		require.Error(t, err)
+		if tc.isErr != nil {
+			require.ErrorIs(t, err, tc.isErr)
+		}
+	}
+
+	_, err = Cast[int8](128)
+	require.EqualError(t, err, "can't cast int to int8, value 128 overflows int8, value out of range")
+
+	// Bools and strings.
+	b, err := Cast[bool]("yes")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = Cast[bool](0.0)
+	require.NoError(t, err)
+	assert.False(t, b)
+
+	for v, expected := range map[any]string{
+		"text":                "text",
+		name("text"):          "text",
+		json.Number("1.50"):   "1.50",
+		42:                    "42",
+		uint8(42):             "42",
+		1.5:                   "1.5",
+		12345678.0:            "12345678",
+		-1e20:                 "-100000000000000000000",
+		0.000001:              "0.000001",
+		1e21:                  "1e+21",
+		1.5e-7:                "1.5e-07",
+		float32(16777216):     "16777216",
+		float32(0.1):          "0.1",
+		true:                  "true",
+		90 * time.Second:      "1m30s",
+		time.Unix(0, 0).UTC(): "1970-01-01T00:00:00Z",
+	} {
+		s, err := Cast[string](v)
+		require.NoError(t, err, "value %v (%T)", v, v)
+		assert.Equal(t, expected, s, "value %v (%T)", v, v)
+	}
+
+	s, err := Cast[string]([]byte("bytes"))
+	require.NoError(t, err)
+	assert.Equal(t, "bytes", s)
+
+	n, err := Cast[name](42)
+	require.NoError(t, err)
+	assert.EqualValues(t, "42", n)
+
+	// Durations and times.
+	d, err := Cast[time.Duration]("1h30m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	d, err = Cast[time.Duration](1e9)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+
+	_, err = Cast[time.Duration]("90")
+	require.Error(t, err)
+
+	expectedTime := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	for _, v := range []any{
+		"2023-05-01T12:30:00Z",
+		"2023-05-01T12:30:00",
+		"2023-05-01 12:30:00",
+		"Mon, 01 May 2023 12:30:00 +0000",
+		"Mon May  1 12:30:00 2023",
+		expectedTime.Unix(),
+		float64(expectedTime.Unix()),
+		json.Number(strconv.FormatInt(expectedTime.Unix(), 10)),
+	} {
+		tm, err := Cast[time.Time](v)
+		require.NoError(t, err, "value %v (%T)", v, v)
+		assert.True(t, expectedTime.Equal(tm), "value %v (%T)", v, v)
+	}
+
+	tm, err := Cast[time.Time]("2023-05-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), tm)
+
+	_, err = Cast[time.Time]("01/05/2023")
+	require.Error(t, err)
+
+	// Slices, maps and pointers.
+	ints, err := Cast[[]int]([]any{1, 2.0, "3", json.Number("4")})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, ints)
+
+	_, err = Cast[[]int]([]any{1, "two"})
+	require.ErrorContains(t, err, "element #1")
+
+	strs, err := Cast[[]string]([2]int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, strs)
+
+	nilSlice, err := Cast[[]string]([]any(nil))
+	require.NoError(t, err)
+	assert.Nil(t, nilSlice)
+
+	bs, err := Cast[[]byte]("bytes")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bytes"), bs)
+
+	m, err := Cast[map[string]int](map[string]any{"a": 1.0, "b": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+
+	mk, err := Cast[map[int][]string](map[string]any{"1": []any{"a"}, "2": nil})
+	require.NoError(t, err)
+	assert.Equal(t, map[int][]string{1: {"a"}, 2: nil}, mk)
+
+	_, err = Cast[map[string]int](map[string]any{"a": "one"})
+	require.ErrorContains(t, err, "value of the key a")
+
+	_, err = Cast[map[int]int](map[string]any{"one": 1})
+	require.ErrorContains(t, err, "key one")
+
+	_, err = Cast[map[string]int]([]any{1})
+	require.Error(t, err)
+
+	x := 42.0
+	p, err := Cast[*int](&x)
+	require.NoError(t, err)
+	assert.Equal(t, 42, *p)
+
+	p, err = Cast[*int](nil)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	a, err := Cast[any](&x)
+	require.NoError(t, err)
+	assert.Equal(t, &x, a)
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	i, err = g.Cast("42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	_, err = g.Cast("forty two")
+	require.Error(t, err)
+}
+
+func TestMustCast(t *testing.T) {
+	assert.Equal(t, 90*time.Second, MustCast[time.Duration]("1m30s"))
+	assert.Equal(t, []float64{1, 2.5}, MustCast[[]float64]([]any{1, "2.5"}))
+
+	assert.Panics(t, func() {
+		MustCast[int](1.5)
+	})
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	assert.Equal(t, 42, g.MustCast(42.0))
+	assert.Panics(t, func() {
+		g.MustCast("forty two")
+	})
+}
+
+type mapTestBase struct {
+	ID        int       `koanf:"id" json:"id"`
+	CreatedAt time.Time `koanf:"created_at" json:"created_at"`
+}
+
+type mapTestDatabase struct {
+	DSN      string        `koanf:"dsn" json:"dsn"`
+	PoolSize int           `koanf:"pool_size" json:"pool_size,omitempty"`
+	Timeout  time.Duration `koanf:"timeout" json:"timeout"`
+}
+
+type mapTestConfig struct {
+	mapTestBase
+	Host     string             `koanf:"host" json:"host"`
+	Port     uint16             `koanf:"port" json:"port,omitempty"`
+	Debug    bool               `koanf:"debug" json:"-"`
+	Tags     []string           `koanf:"tags" json:"tags,omitempty"`
+	Database mapTestDatabase    `koanf:"database" json:"db"`
+	Replica  *mapTestDatabase   `koanf:"replica" json:"replica,omitempty"`
+	Backends []mapTestDatabase  `koanf:"backends" json:"backends,omitempty"`
+	Limits   map[string]float64 `koanf:"limits" json:"limits,omitempty"`
+	Level    *int               `koanf:"level" json:"level,omitempty"`
+	password string
+}
+
+func newMapTestConfig() *mapTestConfig {
+	level := 3
+
+	return &mapTestConfig{
+		mapTestBase: mapTestBase{ID: 1, CreatedAt: time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)},
+		Host:        "localhost",
+		Port:        8080,
+		Debug:       true,
+		Tags:        []string{"a", "b"},
+		Database:    mapTestDatabase{DSN: "postgres://localhost", PoolSize: 10, Timeout: time.Second},
+		Backends:    []mapTestDatabase{{DSN: "redis://localhost"}},
+		Limits:      map[string]float64{"rps": 1.5},
+		Level:       &level,
+		password:    "secret",
+	}
+}
+
+func TestStructToMap(t *testing.T) {
+	c := newMapTestConfig()
+
+	m, err := StructToMap(c, "koanf")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":         1,
+		"created_at": time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC),
+		"host":       "localhost",
+		"port":       uint16(8080),
+		"debug":      true,
+		"tags":       []string{"a", "b"},
+		"database":   map[string]any{"dsn": "postgres://localhost", "pool_size": 10, "timeout": time.Second},
+		"replica":    nil,
+		"backends":   []any{map[string]any{"dsn": "redis://localhost", "pool_size": 0, "timeout": time.Duration(0)}},
+		"limits":     map[string]float64{"rps": 1.5},
+		"level":      3,
+	}, m)
+
+	// JSON tags with omitempty and ignored fields.
+	c.Port = 0
+	c.Backends = nil
+
+	m, err = StructToMap(c, "json")
+	require.NoError(t, err)
+	assert.NotContains(t, m, "port")
+	assert.NotContains(t, m, "debug")
+	assert.NotContains(t, m, "replica")
+	assert.NotContains(t, m, "backends")
+	assert.NotContains(t, m, "password")
+	assert.Equal(t, map[string]any{"dsn": "postgres://localhost", "pool_size": 10, "timeout": time.Second}, m["db"])
+
+	// Flat keys.
+	m, err = StructToMap(c, "koanf", MapOptions{Flatten: true})
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://localhost", m["database.dsn"])
+	assert.Equal(t, 10, m["database.pool_size"])
+	assert.Equal(t, 1.5, m["limits"].(map[string]float64)["rps"])
+	assert.NotContains(t, m, "database")
+
+	m, err = StructToMap(c, "koanf", MapOptions{Flatten: true, Delimiter: "__"})
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://localhost", m["database__dsn"])
+
+	// Without tags.
+	m, err = StructToMap(&struct {
+		Name  string
+		Inner struct{ Value int }
+	}{Name: "name"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"Name": "name", "Inner": map[string]any{"Value": 0}}, m)
+
+	_, err = StructToMap[mapTestConfig](nil, "koanf")
+	require.Error(t, err)
+
+	_, err = StructToMap(&[]int{1}, "koanf")
+	require.Error(t, err)
+
+	g := GenericUtility[mapTestConfig, any]{} // tests for method
+
+	m, err = g.StructToMap(c, "koanf")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", m["host"])
+}
+
+func TestMapToStruct(t *testing.T) {
+	m := map[string]any{
+		"id":         1.0,
+		"created_at": "2023-05-01T12:30:00Z",
+		"HOST":       "localhost",
+		"port":       "8080",
+		"debug":      "on",
+		"tags":       []any{"a", "b"},
+		"database":   map[string]any{"dsn": "postgres://localhost", "pool_size": json.Number("10"), "timeout": "1s"},
+		"backends":   []any{map[string]any{"dsn": "redis://localhost"}},
+		"limits":     map[string]any{"rps": "1.5"},
+		"level":      3,
+		"unknown":    "skipped",
+		"password":   "skipped",
+	}
+
+	expected := newMapTestConfig()
+	expected.password = ""
+
+	c, err := MapToStruct(m, &mapTestConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	// Round trip with the JSON tags.
+	jm, err := StructToMap(expected, "json")
+	require.NoError(t, err)
+	assert.Contains(t, jm, "db")
+
+	c, err = MapToStruct(jm, &mapTestConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, expected.Database, c.Database)
+
+	// Flat keys.
+	c, err = MapToStruct(map[string]any{
+		"host":              "example.com",
+		"database.dsn":      "postgres://example.com",
+		"database.timeout":  int64(time.Minute),
+		"replica.pool_size": 5,
+	}, &mapTestConfig{Port: 3000}, MapOptions{Flatten: true})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", c.Host)
+	assert.EqualValues(t, 3000, c.Port) // existing value is kept
+	assert.Equal(t, mapTestDatabase{DSN: "postgres://example.com", Timeout: time.Minute}, c.Database)
+	assert.Equal(t, &mapTestDatabase{PoolSize: 5}, c.Replica)
+
+	_, err = MapToStruct(map[string]any{"database": "dsn", "database.dsn": "dsn"}, &mapTestConfig{}, MapOptions{Flatten: true})
+	require.Error(t, err)
+
+	// Nested maps are merged with flat keys, but never changed.
+	database := map[string]any{"dsn": "postgres://localhost"}
+	for i := 0; i < 10; i++ { // order of keys is random
+		c, err = MapToStruct(map[string]any{
+			"database":         database,
+			"database.timeout": "1s",
+		}, &mapTestConfig{}, MapOptions{Flatten: true})
+		require.NoError(t, err)
+		assert.Equal(t, mapTestDatabase{DSN: "postgres://localhost", Timeout: time.Second}, c.Database)
+		assert.Equal(t, map[string]any{"dsn": "postgres://localhost"}, database)
+
+		_, err = MapToStruct(map[string]any{
+			"database":     database,
+			"database.dsn": "postgres://example.com",
+		}, &mapTestConfig{}, MapOptions{Flatten: true})
+		require.Error(t, err)
+		assert.Equal(t, map[string]any{"dsn": "postgres://localhost"}, database)
+	}
+
+	// Null values.
+	c, err = MapToStruct(map[string]any{"host": nil, "replica": nil}, &mapTestConfig{Host: "localhost", Replica: &mapTestDatabase{}})
+	require.NoError(t, err)
+	assert.Empty(t, c.Host)
+	assert.Nil(t, c.Replica)
+
+	// Errors.
+	_, err = MapToStruct(map[string]any{"port": 70000}, &mapTestConfig{})
+	require.ErrorIs(t, err, strconv.ErrRange)
+	require.ErrorContains(t, err, "field port")
+
+	_, err = MapToStruct(map[string]any{"database": "dsn"}, &mapTestConfig{})
+	require.Error(t, err)
+
+	// Nil embedded pointer to the unexported struct can't be allocated.
+	type embedded struct {
+		*mapTestDatabase
+		Host string `koanf:"host"`
+	}
+
+	_, err = MapToStruct(map[string]any{"dsn": "postgres://localhost"}, &embedded{})
+	require.ErrorContains(t, err, "field dsn")
+
+	e, err := MapToStruct(map[string]any{"dsn": "postgres://localhost"}, &embedded{mapTestDatabase: &mapTestDatabase{}})
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://localhost", e.DSN)
+
+	_, err = MapToStruct[mapTestConfig](m, nil)
+	require.Error(t, err)
+
+	_, err = MapToStruct(m, &[]int{})
+	require.Error(t, err)
+
+	// Structs with the Cast function.
+	db, err := Cast[mapTestDatabase](map[string]any{"dsn": "postgres://localhost", "pool_size": "10"})
+	require.NoError(t, err)
+	assert.Equal(t, mapTestDatabase{DSN: "postgres://localhost", PoolSize: 10}, db)
+
+	g := GenericUtility[mapTestConfig, any]{} // tests for method
+
+	c, err = g.MapToStruct(map[string]any{"host": "localhost"}, &mapTestConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", c.Host)
+}
+
+func TestParseByteSize(t *testing.T) {
+	for s, expected := range map[string]ByteSize{
+		"0":         0,
+		"1024":      1024,
+		"1024B":     1024,
+		"512MiB":    512 * MiB,
+		"512 MiB":   512 * MiB,
+		"512mib":    512 * MiB,
+		"512Mi":     512 * MiB,
+		"1.5GB":     1500 * MB,
+		"1.5gb":     1500 * MB,
+		"1.5G":      1500 * MB,
+		"1k":        KB,
+		"1KiB":      KiB,
+		"0.5KiB":    512,
+		"0.1KiB":    102,
+		"0.0005KB":  1,
+		" 2TB ":     2 * TB,
+		"3PiB":      3 * PiB,
+		"1.5EiB":    EiB + EiB/2,
+		"15EiB":     15 * EiB,
+		"1.":        1,
+		".5K":       500,
+		"18EB":      18 * EB,
+		"7.25 MB":   7250 * KB,
+		"100000000": 100 * MB,
+	} {
+		size, err := ParseByteSize(s)
+		require.NoError(t, err, "string %s", s)
+		assert.Equal(t, expected, size, "string %s", s)
+	}
+
+	for _, s := range []string{"", "MB", "-1MB", "1.2.3MB", "1 XB", "1MBB", "1e3", ".", "1 M B"} {
+		_, err := ParseByteSize(s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	_, err := ParseByteSize("16EiB")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	g := Utility{} // tests for method
+
+	size, err := g.ParseByteSize("1MiB")
+	require.NoError(t, err)
+	assert.Equal(t, MiB, size)
+}
+
+func TestFormatByteSize(t *testing.T) {
+	for _, tc := range []struct {
+		size     ByteSize
+		iec, si  string
+		exactIEC string
+	}{
+		{0, "0B", "0B", "0B"},
+		{999, "999B", "999B", "999B"},
+		{1000, "1000B", "1KB", "1KB"},
+		{1024, "1KiB", "1.02KB", "1KiB"},
+		{1536 * MiB, "1.5GiB", "1.61GB", "1.5GiB"},
+		{512 * MiB, "512MiB", "536.87MB", "512MiB"},
+		{1500 * MB, "1.4GiB", "1.5GB", "1.5GB"},
+		{1234567, "1.18MiB", "1.23MB", "1234567B"},
+		{ByteSize(math.MaxUint64), "15.99EiB", "18.44EB", "18446744073709551615B"},
+		{15*EiB + 1019*PiB, "15.99EiB", "18.44EB", "18441114574175338496B"}, // truncated
+		{18440 * PB, "15.99EiB", "18.44EB", "18.44EB"},
+		{999_999, "976.56KiB", "1000KB", "999999B"},
+	} {
+		assert.Equal(t, tc.iec, FormatByteSize(tc.size, true), "size %d", tc.size)
+		assert.Equal(t, tc.si, FormatByteSize(tc.size, false), "size %d", tc.size)
+		assert.Equal(t, tc.iec, tc.size.String(), "size %d", tc.size)
+
+		text, err := tc.size.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, tc.exactIEC, string(text), "size %d", tc.size)
+
+		var size ByteSize
+		require.NoError(t, size.UnmarshalText(text))
+		assert.Equal(t, tc.size, size, "size %d", tc.size)
+	}
+
+	// Formatted sizes can be parsed back (near the maximum too).
+	for _, size := range []ByteSize{math.MaxUint64, math.MaxUint64 - 5*PiB, 15*EiB + 1019*PiB - 1, 18445 * PB, 123456789} {
+		for _, iec := range []bool{true, false} {
+			s := FormatByteSize(size, iec)
+			_, err := ParseByteSize(s)
+			require.NoError(t, err, "size %d, string %s", size, s)
+		}
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "1MB", g.FormatByteSize(MB, false))
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"0":         0,
+		"1h30m":     90 * time.Minute,
+		"-1.5h":     -90 * time.Minute,
+		"1d":        24 * time.Hour,
+		"2d4h":      52 * time.Hour,
+		"1w":        7 * 24 * time.Hour,
+		"1w2d":      9 * 24 * time.Hour,
+		"1.5d":      36 * time.Hour,
+		"0.5w":      84 * time.Hour,
+		"-1d12h30m": -(36*time.Hour + 30*time.Minute),
+		"+1d":       24 * time.Hour,
+		"1d500ms":   24*time.Hour + 500*time.Millisecond,
+		"1d1µs":     24*time.Hour + time.Microsecond,
+		"4h1d":      28 * time.Hour,
+	} {
+		d, err := ParseDuration(s)
+		require.NoError(t, err, "string %s", s)
+		assert.Equal(t, expected, d, "string %s", s)
+	}
+
+	for _, s := range []string{"", "d", "1", "1dd", "1d1", "1x", "1d-1h", "1.2.3d", "-", "1d 2h"} {
+		_, err := ParseDuration(s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	_, err := ParseDuration("20000w")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = ParseDuration("15000w15000w")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	g := Utility{} // tests for method
+
+	d, err := g.ParseDuration("1w")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, d)
+}
+
+func TestByteSizeAndDuration_Decoding(t *testing.T) {
+	type config struct {
+		MaxSize   ByteSize  `json:"max_size" koanf:"max_size"`
+		CacheSize ByteSize  `json:"cache_size" koanf:"cache_size"`
+		Timeout   Duration  `json:"timeout" koanf:"timeout"`
+		TTL       Duration  `json:"ttl" koanf:"ttl"`
+		Retention *Duration `json:"retention" koanf:"retention"`
+	}
+
+	expected := &config{
+		MaxSize:   512 * MiB,
+		CacheSize: 1024,
+		Timeout:   Duration(52 * time.Hour),
+		TTL:       Duration(time.Second),
+	}
+
+	// JSON documents.
+	c, err := Unmarshal([]byte(`{"max_size":"512MiB","cache_size":1024,"timeout":"2d4h","ttl":1000000000,"retention":null}`), &config{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	data, err := Marshal(c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"max_size":"512MiB","cache_size":"1KiB","timeout":"52h0m0s","ttl":"1s","retention":null}`, string(data))
+
+	_, err = Unmarshal([]byte(`{"max_size":"512XB"}`), &config{})
+	require.Error(t, err)
+
+	_, err = Unmarshal([]byte(`{"timeout":"2x"}`), &config{})
+	require.Error(t, err)
+
+	_, err = Unmarshal([]byte(`{"timeout":1.5}`), &config{})
+	require.Error(t, err)
+
+	// Config files.
+	dir := t.TempDir()
+
+	path := dir + "/config.yaml"
+	require.NoError(t, os.WriteFile(path, []byte("max_size: 512MiB\ncache_size: 1024\ntimeout: 2d4h\nttl: 1s\n"), 0o600))
+
+	c, err = ParseFileToStruct(path, &config{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	path = dir + "/config.toml"
+	require.NoError(t, os.WriteFile(path, []byte("max_size = \"512 MiB\"\ncache_size = \"1KiB\"\ntimeout = \"2d4h\"\nttl = \"1s\"\nretention = \"1w\"\n"), 0o600))
+
+	c, err = ParseFileToStruct(path, &config{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 512*MiB, c.MaxSize)
+	assert.EqualValues(t, 7*24*time.Hour, *c.Retention)
+
+	// Dynamic values.
+	c, err = MapToStruct(map[string]any{"max_size": "512MiB", "cache_size": 1024.0, "timeout": "2d4h", "ttl": "1s"}, &config{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	d, err := Cast[time.Duration]("1w")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, d)
+}
+
+func BenchmarkEncodeBase58_32Bytes(b *testing.B) {
+	data := bytes.Repeat([]byte{0xab}, 32)
+
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = EncodeBase58(data)
+	}
+	resultConvertersString = r
+}
+
+func BenchmarkEncodeUint64Base62(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = EncodeUint64Base62(1234567890)
+	}
+	resultConvertersString = r
+}
+
+func TestBase58(t *testing.T) {
+	// Test vectors from the Bitcoin Core.
+	for hexData, expected := range map[string]string{
+		"":                     "",
+		"61":                   "2g",
+		"626262":               "a3gV",
+		"636363":               "aPEr",
+		"00000000000000000000": "1111111111",
+		"000111d38e5fc9071ffcd20b4a763cc9ae4f252bb4e48fd66a835e252ada93ff480d6dd43dc62a641155a5": "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz",
+		"00eb15231dfceb60925886b67d065299925915aeb172c06647":                                     "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L",
+		"516b6fcd0f":           "ABnLTmg",
+		"bf4f89001e670274dd":   "3SEo3LWLoPntC",
+		"572e4794":             "3EFU7m",
+		"ecac89cad93923c02321": "EJDM8drfXA6uyA",
+		"10c8511e":             "Rt5zm",
+		"73696d706c792061206c6f6e6720737472696e67": "2cFupjhnEsSn59qHXstmK2ffpLv2",
+	} {
+		data, err := hex.DecodeString(hexData)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, EncodeBase58(data), "data %s", hexData)
+
+		decoded, err := DecodeBase58(expected)
+		require.NoError(t, err, "string %s", expected)
+		assert.Equal(t, hexData, hex.EncodeToString(decoded), "string %s", expected)
+	}
+
+	for _, s := range []string{"0", "O", "I", "l", "3mJr0", "abc+", "ы"} {
+		_, err := DecodeBase58(s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", g.EncodeBase58([]byte("Hello World!")))
+
+	b, err := g.DecodeBase58("2NEpo7TZRRrLZSi2U")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World!", string(b))
+}
+
+func TestBase62(t *testing.T) {
+	assert.Equal(t, "", EncodeBase62(nil))
+	assert.Equal(t, "0", EncodeBase62([]byte{0}))
+	assert.Equal(t, "001", EncodeBase62([]byte{0, 0, 1}))
+	assert.Equal(t, "47", EncodeBase62([]byte{0xff}))
+	assert.Equal(t, "H31", EncodeBase62([]byte{0xff, 0xff}))
+
+	// Compare with the math/big package (it has the "0-9a-zA-Z" alphabet).
+	swapCase := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case unicode.IsLower(r):
+				return unicode.ToUpper(r)
+			case unicode.IsUpper(r):
+				return unicode.ToLower(r)
+			default:
+				return r
+			}
+		}, s)
+	}
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 200; i++ {
+		data := make([]byte, 1+rnd.IntN(64))
+		for j := range data {
+			data[j] = byte(rnd.Uint32())
+		}
+		data[0] |= 1 // no leading zeros
+
+		s := EncodeBase62(data)
+		assert.Equal(t, swapCase(new(big.Int).SetBytes(data).Text(62)), s)
+
+		decoded, err := DecodeBase62(s)
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+
+		// Round trip for the Base58 too.
+		decoded, err = DecodeBase58(EncodeBase58(append([]byte{0, 0}, data...)))
+		require.NoError(t, err)
+		assert.Equal(t, append([]byte{0, 0}, data...), decoded)
+	}
+
+	decoded, err := DecodeBase62("")
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	for _, s := range []string{"-1", "abc_", "a b", "ы"} {
+		_, err := DecodeBase62(s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "H31", g.EncodeBase62([]byte{0xff, 0xff}))
+
+	b, err := g.DecodeBase62("H31")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xff}, b)
+}
+
+func TestUint64Base62(t *testing.T) {
+	for n, expected := range map[uint64]string{
+		0:                  "0",
+		9:                  "9",
+		10:                 "A",
+		61:                 "z",
+		62:                 "10",
+		1234567890:         "1LY7VK",
+		math.MaxUint64:     "LygHa16AHYF",
+		math.MaxUint64 - 1: "LygHa16AHYE",
+	} {
+		assert.Equal(t, expected, EncodeUint64Base62(n), "number %d", n)
+
+		decoded, err := DecodeUint64Base62(expected)
+		require.NoError(t, err, "string %s", expected)
+		assert.Equal(t, n, decoded, "string %s", expected)
+	}
+
+	decoded, err := DecodeUint64Base62("00000001")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, decoded)
+
+	_, err = DecodeUint64Base62("")
+	require.Error(t, err)
+
+	_, err = DecodeUint64Base62("1_0")
+	require.Error(t, err)
+
+	_, err = DecodeUint64Base62("LygHa16AHYG")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = DecodeUint64Base62("100000000000")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "1LY7VK", g.EncodeUint64Base62(1234567890))
+
+	n, err := g.DecodeUint64Base62("1LY7VK")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1234567890, n)
+}
+
+func TestBase32Crockford(t *testing.T) {
+	for data, expected := range map[string]string{
+		"":       "",
+		"f":      "CR",
+		"fo":     "CSQG",
+		"foo":    "CSQPY",
+		"foob":   "CSQPYRG",
+		"fooba":  "CSQPYRK1",
+		"foobar": "CSQPYRK1E8",
+	} {
+		assert.Equal(t, expected, EncodeBase32Crockford([]byte(data)), "data %s", data)
+
+		decoded, err := DecodeBase32Crockford(expected)
+		require.NoError(t, err, "string %s", expected)
+		assert.Equal(t, data, string(decoded), "string %s", expected)
+	}
+
+	// Case-insensitive decoding with aliases and hyphens.
+	decoded, err := DecodeBase32Crockford("csqp-yrk1-e8")
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(decoded))
+
+	a, err := DecodeBase32Crockford("I0")
+	require.NoError(t, err)
+
+	b, err := DecodeBase32Crockford("LO")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// Non-canonical strings with non-zero trailing bits are rejected.
+	for _, s := range []string{"0Z", "0I", "CS", "CSQH", "CSQPZ", "CSQPYRH", "CSQPYRK1E9"} {
+		_, err := DecodeBase32Crockford(s)
+		assert.ErrorContains(t, err, "trailing bits", "string %s", s)
+	}
+
+	for _, s := range []string{"U", "CSQPYRK1E*", "C", "CSQ", "ы"} {
+		_, err := DecodeBase32Crockford(s)
+		assert.Error(t, err, "string %s", s)
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "CSQPYRK1E8", g.EncodeBase32Crockford([]byte("foobar")))
+
+	decoded, err = g.DecodeBase32Crockford("CSQPYRK1E8")
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(decoded))
+}
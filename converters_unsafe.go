@@ -0,0 +1,22 @@
+//go:build !gosl_safe && !gosl_debug
+
+package gosl
+
+import "unsafe"
+
+// SafeMode reports whether the package was built with the "gosl_safe" (or
+// "gosl_debug") build tag. In the safe mode, the ToBytes, ToString and Concat
+// functions copy data instead of aliasing the memory with the "unsafe" package.
+const SafeMode = false
+
+// stringToBytes returns the byte slice, which aliases memory of the given
+// string. The result must not be modified.
+func stringToBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// bytesToString returns the string, which aliases memory of the given byte
+// slice. The byte slice must not be modified after the call.
+func bytesToString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,453 @@
+package gosl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FakeOptions represents options for the FakeFill function.
+type FakeOptions struct {
+	// Seed sets the seed for the reproducible values (the same seed gives the
+	// same values). If zero, values are random.
+	Seed uint64
+
+	// Generator sets the source of randomness (it takes precedence over the
+	// Seed option).
+	Generator *Generator
+
+	// MinLen and MaxLen set bounds of the length for slices and maps (from 1
+	// to 3 by default).
+	MinLen, MaxLen int
+}
+
+// fakeMaxDepth represents the maximum depth of the nested values (for ex., of
+// the recursive types), which are filled by the FakeFill function.
+const fakeMaxDepth = 6
+
+// Words for the fake values.
+var (
+	fakeFirstNames = []string{
+		"Anna", "Viktor", "Olga", "James", "Maria", "David", "Elena", "Robert", "Sofia", "Thomas",
+	}
+	fakeLastNames = []string{
+		"Smith", "Ivanova", "Garcia", "Müller", "Rossi", "Novak", "Johnson", "Kowalski", "Petrov", "Brown",
+	}
+	fakeWords = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "labore", "dolore", "magna", "aliqua", "enim", "minim", "veniam",
+	}
+	fakeDomains = []string{"example.com", "example.org", "example.net"}
+)
+
+// Range of the fake time.Time values.
+var (
+	fakeTimeMin = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fakeTimeMax = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+var (
+	uuidType = reflect.TypeFor[UUID]()
+	ulidType = reflect.TypeFor[ULID]()
+)
+
+// FakeFill fills all exported fields of the given struct *T (recursively) with
+// random, but type-correct values for the test fixtures.
+//
+// Values of the fields can be set by the "fake" tag:
+//   - "email", "name", "url" or "uuid" for the fake strings;
+//   - "int:1-100" for a number in the range (inclusive);
+//   - "oneof:a,b,c" for one of the given values;
+//   - "-" for skipping the field.
+//
+// Tags of the slices and maps are applied to their elements. Values of the
+// tags are converted like the Cast function does (for ex., "int:1-10" gives
+// nanoseconds for a time.Duration and Unix seconds for a time.Time).
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID    int      `fake:"int:1-100"`
+//		Name  string   `fake:"name"`
+//		Email string   `fake:"email"`
+//		Role  string   `fake:"oneof:admin,user"`
+//		Tags  []string
+//	}
+//
+//	func main() {
+//		u, err := gosl.FakeFill(&user{}, gosl.FakeOptions{Seed: 42})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Printf("%+v\n", u) // the same values for the same seed
+//	}
+func FakeFill[T any](model *T, opts ...FakeOptions) (*T, error) {
+	if model == nil {
+		return nil, errors.New("error: given struct is nil")
+	}
+
+	f := &faker{g: defaultGenerator, minLen: 1, maxLen: 3}
+
+	if len(opts) > 0 {
+		o := opts[0]
+
+		switch {
+		case o.Generator != nil:
+			f.g = o.Generator
+		case o.Seed != 0:
+			f.g = NewGenerator(NewSeededReader(o.Seed))
+		}
+
+		if o.MinLen > 0 || o.MaxLen > 0 {
+			f.minLen, f.maxLen = o.MinLen, o.MaxLen
+		}
+	}
+
+	if f.minLen < 0 || f.maxLen < f.minLen {
+		return nil, fmt.Errorf("error: invalid bounds of the length (from %d to %d)", f.minLen, f.maxLen)
+	}
+
+	if err := f.fill(reflect.ValueOf(model).Elem(), "", 0); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// faker represents a state of the FakeFill function.
+type faker struct {
+	g              *Generator
+	minLen, maxLen int
+}
+
+// fill fills the given value with the fake data.
+func (f *faker) fill(v reflect.Value, tag string, depth int) error {
+	if tag == "-" || depth > fakeMaxDepth {
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType, durationType, uuidType, ulidType:
+		if tag != "" {
+			return f.fillTagged(v, tag)
+		}
+	}
+
+	switch v.Type() {
+	case timeType:
+		n, err := f.intn(uint64(fakeTimeMax.Unix() - fakeTimeMin.Unix()))
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(fakeTimeMin.Add(time.Duration(n) * time.Second)))
+		return nil
+	case durationType:
+		n, err := f.intn(uint64(24 * time.Hour / time.Second))
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(time.Duration(n) * time.Second))
+		return nil
+	case uuidType, ulidType:
+		var id fmt.Stringer
+		var err error
+		if v.Type() == uuidType {
+			id, err = f.g.NewUUIDv4()
+		} else {
+			id, err = f.g.NewULID()
+		}
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(id))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return f.fill(v.Elem(), tag, depth+1)
+	case reflect.Struct:
+		t := v.Type()
+
+		// Resolve fields by the names only (including promoted fields of the
+		// embedded structs), since values of the "fake" tag aren't names.
+		for _, field := range cachedStructFields(t, "") {
+			tag, skip := fakeFieldTag(t, field.index)
+			if skip {
+				continue
+			}
+
+			fv, ok := fieldByIndex(v, field.index, true)
+			if !ok {
+				continue // skip fields of the embedded pointer to unexported struct
+			}
+
+			if err := f.fill(fv, tag, depth+1); err != nil {
+				return fmt.Errorf("can't fill field %s, %w", field.name, err)
+			}
+		}
+		return nil
+	case reflect.Slice:
+		n, err := f.length()
+		if err != nil {
+			return err
+		}
+
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := f.fill(s.Index(i), tag, depth+1); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := f.fill(v.Index(i), tag, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		n, err := f.length()
+		if err != nil {
+			return err
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := f.fill(key, "", depth+1); err != nil {
+				return err
+			}
+
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := f.fill(elem, tag, depth+1); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil // no fake values for these kinds
+	}
+
+	if tag != "" {
+		return f.fillTagged(v, tag)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		n, err := f.intn(2)
+		if err != nil {
+			return err
+		}
+		v.SetBool(n == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := f.intn(min(1000, uint64(math.MaxInt64)>>(64-v.Type().Bits())) + 1)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := f.intn(min(1000, uint64(math.MaxUint64)>>(64-v.Type().Bits())) + 1)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := randomFloat64(f.g.r)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Round(n*1000_00) / 100) // from 0 to 1000 with 2 decimals
+	case reflect.String:
+		s, err := f.pick(fakeWords)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	}
+
+	return nil
+}
+
+// fakeFieldTag returns the "fake" tag of the nested field of the struct by the
+// given index. If the field or one of its embedded structs has the "-" tag,
+// returns true for skipping the field.
+func fakeFieldTag(t reflect.Type, index []int) (string, bool) {
+	var tag string
+	for i := range index {
+		tag = t.FieldByIndex(index[:i+1]).Tag.Get("fake")
+		if tag == "-" {
+			return "", true
+		}
+	}
+
+	return tag, false
+}
+
+// fillTagged fills the given value with the fake data by the tag.
+func (f *faker) fillTagged(v reflect.Value, tag string) error {
+	name, arg, _ := strings.Cut(tag, ":")
+
+	var value any
+	var err error
+
+	switch name {
+	case "email":
+		value, err = f.email()
+	case "name":
+		value, err = f.name()
+	case "url":
+		value, err = f.url()
+	case "uuid":
+		var id UUID
+		id, err = f.g.NewUUIDv4()
+		value = id.String()
+	case "int":
+		value, err = f.intRange(arg)
+	case "oneof":
+		value, err = f.pick(strings.Split(arg, ","))
+	default:
+		return fmt.Errorf("error: unknown fake tag (%s)", tag)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = castValue(v, reflect.ValueOf(value)); err != nil {
+		return fmt.Errorf("can't set value of the fake tag (%s) to %s, %w", tag, v.Type(), err)
+	}
+
+	return nil
+}
+
+// intn returns a random number in [0, n).
+func (f *faker) intn(n uint64) (uint64, error) {
+	return randomUint64n(f.g.r, n)
+}
+
+// length returns a random length for the slices and maps.
+func (f *faker) length() (int, error) {
+	n, err := f.intn(uint64(f.maxLen - f.minLen + 1))
+	if err != nil {
+		return 0, err
+	}
+
+	return f.minLen + int(n), nil
+}
+
+// pick returns a random element of the given list.
+func (f *faker) pick(list []string) (string, error) {
+	i, err := f.intn(uint64(len(list)))
+	if err != nil {
+		return "", err
+	}
+
+	return list[i], nil
+}
+
+// name returns a random full name (for ex., "Anna Smith").
+func (f *faker) name() (string, error) {
+	first, err := f.pick(fakeFirstNames)
+	if err != nil {
+		return "", err
+	}
+
+	last, err := f.pick(fakeLastNames)
+	if err != nil {
+		return "", err
+	}
+
+	return first + " " + last, nil
+}
+
+// email returns a random email on the reserved domain (for ex.,
+// "anna.smith@example.com").
+func (f *faker) email() (string, error) {
+	first, err := f.pick(fakeFirstNames)
+	if err != nil {
+		return "", err
+	}
+
+	word, err := f.pick(fakeWords)
+	if err != nil {
+		return "", err
+	}
+
+	domain, err := f.pick(fakeDomains)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(first) + "." + word + "@" + domain, nil
+}
+
+// url returns a random URL on the reserved domain (for ex.,
+// "https://example.com/lorem/ipsum").
+func (f *faker) url() (string, error) {
+	domain, err := f.pick(fakeDomains)
+	if err != nil {
+		return "", err
+	}
+
+	first, err := f.pick(fakeWords)
+	if err != nil {
+		return "", err
+	}
+
+	second, err := f.pick(fakeWords)
+	if err != nil {
+		return "", err
+	}
+
+	return "https://" + domain + "/" + first + "/" + second, nil
+}
+
+// intRange returns a random number in the range from the argument of the "int"
+// tag (for ex., "1-100" or "-10-10").
+func (f *faker) intRange(arg string) (int64, error) {
+	sep := strings.IndexByte(arg[min(1, len(arg)):], '-') + min(1, len(arg))
+	if sep < 1 {
+		return 0, fmt.Errorf("error: invalid range (%s) of the fake tag, use \"int:min-max\"", arg)
+	}
+
+	lo, err := strconv.ParseInt(arg[:sep], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error: invalid range (%s) of the fake tag, %w", arg, err)
+	}
+
+	hi, err := strconv.ParseInt(arg[sep+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error: invalid range (%s) of the fake tag, %w", arg, err)
+	}
+
+	if hi < lo {
+		return 0, fmt.Errorf("error: invalid range (%s) of the fake tag, min is greater than max", arg)
+	}
+
+	return randomInt(f.g.r, lo, hi) // including the full range of an int64
+}
@@ -0,0 +1,277 @@
+package gosl
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAddress struct {
+	City    string `fake:"oneof:Moscow,Berlin,Paris"`
+	ZipCode int    `fake:"int:10000-99999"`
+}
+
+type fakeUser struct {
+	ID        int64    `fake:"int:1-100"`
+	UUID      string   `fake:"uuid"`
+	Name      string   `fake:"name"`
+	Email     string   `fake:"email"`
+	Website   string   `fake:"url"`
+	Role      string   `fake:"oneof:admin,user"`
+	Level     uint8    `fake:"oneof:1,2,3"`
+	Balance   float64  `fake:"int:-10-10"`
+	Emails    []string `fake:"email"`
+	Scores    map[string]int
+	Active    bool
+	Age       uint8
+	Big       uint64
+	Ratio     float32
+	Bio       string
+	Skipped   string `fake:"-"`
+	CreatedAt time.Time
+	Timeout   time.Duration
+	MaxSize   ByteSize
+	Key       UUID
+	Token     ULID
+	Address   fakeAddress
+	Manager   *fakeUser
+	Friends   []*fakeUser
+	Hash      [4]byte
+	Any       any
+	private   string
+}
+
+func TestFakeFill(t *testing.T) {
+	u, err := FakeFill(&fakeUser{}, FakeOptions{MinLen: 2, MaxLen: 4})
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, u.ID, int64(1))
+	assert.LessOrEqual(t, u.ID, int64(100))
+	assert.True(t, IsValidUUID(u.UUID))
+	assert.Len(t, strings.Fields(u.Name), 2)
+	assert.Regexp(t, `^[a-z]+\.[a-z]+@example\.(com|org|net)$`, u.Email)
+	assert.Contains(t, []string{"admin", "user"}, u.Role)
+	assert.Contains(t, []uint8{1, 2, 3}, u.Level)
+	assert.GreaterOrEqual(t, u.Balance, -10.0)
+	assert.LessOrEqual(t, u.Balance, 10.0)
+	assert.LessOrEqual(t, u.Age, uint8(255))
+	assert.LessOrEqual(t, u.Big, uint64(1000))
+	assert.NotEmpty(t, u.Bio)
+	assert.Empty(t, u.Skipped)
+	assert.Empty(t, u.private)
+	assert.Nil(t, u.Any)
+	assert.False(t, u.CreatedAt.Before(fakeTimeMin))
+	assert.True(t, u.CreatedAt.Before(fakeTimeMax))
+	assert.Less(t, u.Timeout, 24*time.Hour)
+	assert.Equal(t, 4, u.Key.Version())
+	assert.False(t, u.Token.Time().IsZero())
+	assert.Contains(t, []string{"Moscow", "Berlin", "Paris"}, u.Address.City)
+	assert.GreaterOrEqual(t, u.Address.ZipCode, 10000)
+	assert.LessOrEqual(t, u.Address.ZipCode, 99999)
+
+	website, err := url.Parse(u.Website)
+	require.NoError(t, err)
+	assert.Equal(t, "https", website.Scheme)
+
+	// Slices and maps within the length bounds (tags are applied to elements).
+	assert.GreaterOrEqual(t, len(u.Emails), 2)
+	assert.LessOrEqual(t, len(u.Emails), 4)
+	for _, email := range u.Emails {
+		assert.Contains(t, email, "@example.")
+	}
+	assert.GreaterOrEqual(t, len(u.Scores), 1) // keys can be repeated
+	assert.LessOrEqual(t, len(u.Scores), 4)
+
+	// Recursive types are filled up to the maximum depth.
+	require.NotNil(t, u.Manager)
+	assert.NotEmpty(t, u.Manager.Name)
+	require.NotEmpty(t, u.Friends)
+	require.NotNil(t, u.Friends[0])
+	assert.NotEmpty(t, u.Friends[0].Email)
+
+	depth := 0
+	for m := u; m != nil; m = m.Manager {
+		depth++
+	}
+	assert.Less(t, depth, fakeMaxDepth)
+
+	g := GenericUtility[fakeAddress, any]{} // tests for method
+
+	a, err := g.FakeFill(&fakeAddress{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, a.City)
+}
+
+func TestFakeFill_Seed(t *testing.T) {
+	epoch := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	newGenerator := func() *Generator {
+		return NewGenerator(NewSeededReader(42), GeneratorOptions{Now: func() time.Time { return epoch }})
+	}
+
+	u1, err := FakeFill(&fakeUser{}, FakeOptions{Generator: newGenerator()})
+	require.NoError(t, err)
+
+	u2, err := FakeFill(&fakeUser{}, FakeOptions{Generator: newGenerator()})
+	require.NoError(t, err)
+	assert.Equal(t, u1, u2)
+
+	// The same seed gives the same values (without time-based identifiers).
+	a1, err := FakeFill(&fakeAddress{}, FakeOptions{Seed: 7})
+	require.NoError(t, err)
+
+	a2, err := FakeFill(&fakeAddress{}, FakeOptions{Seed: 7})
+	require.NoError(t, err)
+	assert.Equal(t, a1, a2)
+
+	// Different seeds give different values.
+	type numbers struct{ Values []int }
+
+	n1, err := FakeFill(&numbers{}, FakeOptions{Seed: 1, MinLen: 20, MaxLen: 20})
+	require.NoError(t, err)
+
+	n2, err := FakeFill(&numbers{}, FakeOptions{Seed: 2, MinLen: 20, MaxLen: 20})
+	require.NoError(t, err)
+	assert.NotEqual(t, n1, n2)
+}
+
+func TestFakeFill_Errors(t *testing.T) {
+	_, err := FakeFill[fakeUser](nil)
+	require.Error(t, err)
+
+	_, err = FakeFill(&fakeUser{}, FakeOptions{MinLen: 3, MaxLen: 2})
+	require.Error(t, err)
+
+	_, err = FakeFill(&fakeUser{}, FakeOptions{Generator: NewGenerator(iotest.ErrReader(errors.New("failed")))})
+	require.Error(t, err)
+
+	for name, fill := range map[string]func() error{
+		"unknown tag": func() error {
+			_, err := FakeFill(&struct {
+				Value string `fake:"unknown"`
+			}{})
+			return err
+		},
+		"range without max": func() error {
+			_, err := FakeFill(&struct {
+				Value int `fake:"int:10"`
+			}{})
+			return err
+		},
+		"invalid min": func() error {
+			_, err := FakeFill(&struct {
+				Value int `fake:"int:a-10"`
+			}{})
+			return err
+		},
+		"invalid max": func() error {
+			_, err := FakeFill(&struct {
+				Value int `fake:"int:1-a"`
+			}{})
+			return err
+		},
+		"min is greater than max": func() error {
+			_, err := FakeFill(&struct {
+				Value int `fake:"int:10-1"`
+			}{})
+			return err
+		},
+		"out of range": func() error {
+			_, err := FakeFill(&struct {
+				Value int8 `fake:"int:1000-2000"`
+			}{})
+			return err
+		},
+		"email to int": func() error {
+			_, err := FakeFill(&struct {
+				Value int `fake:"email"`
+			}{})
+			return err
+		},
+		"unknown tag of time": func() error {
+			_, err := FakeFill(&struct {
+				When time.Time `fake:"bogus"`
+			}{})
+			return err
+		},
+		"unknown tag of duration": func() error {
+			_, err := FakeFill(&struct {
+				Timeout time.Duration `fake:"bogus"`
+			}{})
+			return err
+		},
+		"invalid element": func() error {
+			_, err := FakeFill(&struct {
+				Values []int `fake:"oneof:one,two"`
+			}{})
+			return err
+		},
+	} {
+		require.Error(t, fill(), "case %s", name)
+	}
+}
+
+type fakeInner struct {
+	Name  string `fake:"name"`
+	Score int    `fake:"int:1-10"`
+}
+
+func TestFakeFill_Embedded(t *testing.T) {
+	type skipped struct {
+		Value string
+	}
+
+	type model struct {
+		fakeInner
+		*skipped
+		Base  fakeAddress `fake:"-"`
+		Extra string
+	}
+
+	m, err := FakeFill(&model{})
+	require.NoError(t, err)
+
+	// Promoted fields of the embedded unexported struct are filled.
+	assert.Len(t, strings.Fields(m.Name), 2)
+	assert.GreaterOrEqual(t, m.Score, 1)
+	assert.LessOrEqual(t, m.Score, 10)
+	assert.NotEmpty(t, m.Extra)
+
+	// Embedded pointer to the unexported struct can't be allocated.
+	assert.Nil(t, m.skipped)
+	assert.Empty(t, m.Base)
+
+	type exported struct {
+		*fakeAddress `fake:"-"`
+		*FakeOptions
+	}
+
+	e, err := FakeFill(&exported{})
+	require.NoError(t, err)
+	assert.Nil(t, e.fakeAddress)
+	assert.NotNil(t, e.FakeOptions) // exported embedded pointer is allocated
+}
+
+func TestFakeFill_TaggedTypes(t *testing.T) {
+	type model struct {
+		Timeout time.Duration `fake:"int:1-10"`
+		When    time.Time     `fake:"int:0-0"`
+		Min     int64         `fake:"int:-9223372036854775808-9223372036854775807"`
+		Max     uint64        `fake:"int:9223372036854775807-9223372036854775807"`
+	}
+
+	for i := 0; i < 10; i++ {
+		m, err := FakeFill(&model{})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, m.Timeout, time.Duration(1))
+		assert.LessOrEqual(t, m.Timeout, time.Duration(10))
+		assert.Equal(t, int64(0), m.When.Unix())
+		assert.Equal(t, uint64(math.MaxInt64), m.Max)
+	}
+}
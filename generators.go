@@ -1,11 +1,283 @@
 package gosl
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/bits"
+	mrand "math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
+// Predefined alphabets for the RandomStringFrom function.
+const (
+	// AlphabetHex represents lowercase hexadecimal digits.
+	AlphabetHex = "0123456789abcdef"
+
+	// AlphabetAlphanumeric represents digits and lowercase latin letters.
+	AlphabetAlphanumeric = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+	// AlphabetBase62 represents digits, uppercase and lowercase latin letters.
+	AlphabetBase62 = base62Alphabet
+
+	// AlphabetURLSafe represents characters, which are safe for URLs and file
+	// names without escaping (like the NanoID alphabet).
+	AlphabetURLSafe = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+	// AlphabetHumanFriendly represents digits and latin letters without the
+	// ambiguous characters (0, O, o, 1, I, l), which are easy to read and type.
+	AlphabetHumanFriendly = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"
+)
+
+// Generator represents a generator of the random strings and identifiers with
+// the given source of randomness (for ex., a deterministic reader for tests).
+//
+// Generator is safe for concurrent use by multiple goroutines (reads from the
+// source are serialized, if the source is not the "crypto/rand" reader).
+type Generator struct {
+	r     io.Reader
+	now   func() time.Time
+	ulids ulidGenerator
+}
+
+// GeneratorOptions represents options for the Generator.
+type GeneratorOptions struct {
+	// Now sets a function, which returns the current time for the time-ordered
+	// identifiers (time.Now by default).
+	Now func() time.Time
+}
+
+// defaultGenerator represents the Generator with the "crypto/rand" reader for
+// the package-level functions.
+var defaultGenerator = NewGenerator(rand.Reader)
+
+// NewGenerator creates a new Generator with the given source of randomness. If
+// r is nil, uses the reader of the built-in "crypto/rand" package.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//		"time"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		g := gosl.NewGenerator(gosl.NewSeededReader(42), gosl.GeneratorOptions{
+//			Now: func() time.Time { return time.Unix(0, 0) },
+//		})
+//
+//		s, err := g.RandomString(8)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // the same string for the same seed
+//	}
+func NewGenerator(r io.Reader, opts ...GeneratorOptions) *Generator {
+	g := &Generator{r: r, now: time.Now}
+
+	switch {
+	case r == nil, r == rand.Reader:
+		g.r = rand.Reader // safe for concurrent use
+	default:
+		g.r = &lockedReader{r: r}
+	}
+
+	if len(opts) > 0 && opts[0].Now != nil {
+		g.now = opts[0].Now
+	}
+
+	return g
+}
+
+// NewSeededReader creates a new deterministic source of randomness with the
+// given seed (the same seed gives the same sequence of bytes) using the
+// ChaCha8 generator of the built-in "math/rand/v2" package.
+//
+// It's designed for tests (for ex., golden files with generated tokens) and
+// must not be used for secrets.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		g := gosl.NewGenerator(gosl.NewSeededReader(42))
+//
+//		id, err := g.NewUUIDv4()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id) // the same UUID for the same seed
+//	}
+func NewSeededReader(seed uint64) io.Reader {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+
+	return mrand.NewChaCha8(key)
+}
+
+// RandomString generates a random hexadecimal string with a given size.
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) RandomString(size int) (string, error) {
+	return randomString(g.r, size)
+}
+
+// RandomStringFrom generates a random string with a given size from the
+// characters of the alphabet (without the modulo bias).
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) RandomStringFrom(size int, alphabet string) (string, error) {
+	return randomStringFrom(g.r, size, alphabet)
+}
+
+// NewUUIDv4 generates a random UUID of the version 4 (RFC 9562).
+//
+// If err != nil returns zero-value for an UUID and error.
+func (g *Generator) NewUUIDv4() (UUID, error) {
+	return newUUIDv4(g.r)
+}
+
+// NewUUIDv7 generates a time-ordered UUID of the version 7 (RFC 9562) with the
+// current Unix time in milliseconds.
+//
+// If err != nil returns zero-value for an UUID and error.
+func (g *Generator) NewUUIDv7() (UUID, error) {
+	return newUUIDv7(g.r, g.now())
+}
+
+// NewULID generates a ULID with the current Unix time in milliseconds, which
+// are monotonically increasing within the same millisecond.
+//
+// If err != nil returns zero-value for an ULID and error.
+func (g *Generator) NewULID() (ULID, error) {
+	return g.ulids.next(g.r, g.now())
+}
+
+// NewNanoID generates a NanoID with the given size (21 by default) from the
+// characters of the alphabet (AlphabetURLSafe by default).
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) NewNanoID(size int, alphabet string) (string, error) {
+	return newNanoID(g.r, size, alphabet)
+}
+
+// GeneratePassword generates a random password, which satisfies the given
+// policy.
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) GeneratePassword(policy PasswordPolicy) (string, error) {
+	return generatePassword(g.r, policy)
+}
+
+// GenerateToken generates a random API token with the given prefix, the Base62
+// body with the given number of random bytes (32 by default, at least 16) and
+// the CRC32 checksum.
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) GenerateToken(prefix string, entropyBytes int) (string, error) {
+	return generateToken(g.r, prefix, entropyBytes)
+}
+
+// RandomIntWith generates a random integer of the type T in the range from
+// min to max (inclusive) with the source of randomness of the given Generator
+// (see RandomInt). If g is nil, uses the reader of the built-in "crypto/rand"
+// package.
+//
+// If err != nil returns zero-value for the type T and error.
+func RandomIntWith[T Integer](g *Generator, min, max T) (T, error) {
+	return randomInt(generatorReader(g), min, max)
+}
+
+// RandomChoiceWith returns a random element of the given slice with the source
+// of randomness of the given Generator (see RandomChoice). If g is nil, uses
+// the reader of the built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for the type T and error.
+func RandomChoiceWith[T any](g *Generator, s []T) (T, error) {
+	return randomChoice(generatorReader(g), s)
+}
+
+// RandomSampleWith returns n random elements of the given slice with the source
+// of randomness of the given Generator (see RandomSample). If g is nil, uses
+// the reader of the built-in "crypto/rand" package.
+//
+// If err != nil returns nil and error.
+func RandomSampleWith[T any](g *Generator, s []T, n int) ([]T, error) {
+	return randomSample(generatorReader(g), s, n)
+}
+
+// ShuffleWith shuffles elements of the given slice in place with the source of
+// randomness of the given Generator (see Shuffle). If g is nil, uses the
+// reader of the built-in "crypto/rand" package.
+//
+// If err != nil returns error (the slice can be partially shuffled).
+func ShuffleWith[T any](g *Generator, s []T) error {
+	return shuffle(generatorReader(g), s)
+}
+
+// WeightedChoiceWith returns a random element of the given slice with the
+// probability, proportional to its weight, with the source of randomness of
+// the given Generator (see WeightedChoice). If g is nil, uses the reader of
+// the built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for the type T and error.
+func WeightedChoiceWith[T any](g *Generator, s []T, weights []float64) (T, error) {
+	return weightedChoice(generatorReader(g), s, weights)
+}
+
+// generatorReader returns the source of randomness of the given Generator or
+// of the defaultGenerator, if g is nil.
+func generatorReader(g *Generator) io.Reader {
+	if g == nil {
+		return defaultGenerator.r
+	}
+
+	return g.r
+}
+
+// lockedReader represents a reader, which is safe for concurrent use by
+// multiple goroutines.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+// Read implements the io.Reader interface.
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.r.Read(p)
+}
+
 // RandomString generates a random string with a given size using built-in
 // "crypto/rand" and "encoding/hex" packages.
 //
@@ -31,17 +303,1392 @@ import (
 //		fmt.Println(s)
 //	}
 func RandomString(size int) (string, error) {
+	return defaultGenerator.RandomString(size)
+}
+
+// RandomStringFrom generates a random string with a given size from the
+// characters of the alphabet (for ex., AlphabetHumanFriendly) using built-in
+// "crypto/rand" package.
+//
+// Characters are selected with the rejection sampling, so each character of
+// the alphabet has the same probability (without the modulo bias). Alphabet
+// must contain from 2 to 128 unique ASCII characters.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.RandomStringFrom(8, gosl.AlphabetHumanFriendly)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s)
+//	}
+func RandomStringFrom(size int, alphabet string) (string, error) {
+	return defaultGenerator.RandomStringFrom(size, alphabet)
+}
+
+// randomString helps to generate a random hexadecimal string with the given
+// source of randomness for the RandomString function.
+func randomString(r io.Reader, size int) (string, error) {
+	if size <= 0 {
+		return "", errors.New("can't generate random string with zero or negative size")
+	}
+
+	bufferSize := (size + 1) / 2 // each byte is encoded to two characters
+
+	b := make([]byte, bufferSize+bufferSize*2)
+	if _, err := io.ReadFull(r, b[:bufferSize]); err != nil {
+		return "", fmt.Errorf("can't generate random string, %w", err)
+	}
+
+	out := b[bufferSize:]
+	hex.Encode(out, b[:bufferSize])
+
+	return unsafe.String(unsafe.SliceData(out), size), nil
+}
+
+// randomStringFrom helps to generate a random string from the characters of
+// the alphabet with the given source of randomness for the RandomStringFrom
+// function.
+func randomStringFrom(r io.Reader, size int, alphabet string) (string, error) {
 	if size <= 0 {
 		return "", errors.New("can't generate random string with zero or negative size")
 	}
 
-	bufferSize := size
-	if size%2 == 0 && size >= 4 {
-		bufferSize = size / 2 // hack to reduce buffer size and B/op
+	if err := validateAlphabet(alphabet); err != nil {
+		return "", err
+	}
+
+	// Mask of the random bytes to get indexes of the alphabet with the
+	// smallest number of rejected bytes (for ex., 63 for 62 characters).
+	mask := byte(1<<bits.Len8(uint8(len(alphabet)-1)) - 1)
+
+	// Estimate the number of random bytes for a single read (with a margin for
+	// the rejected bytes).
+	step := size * int(mask) / len(alphabet) * 8 / 5
+	step = min(max(step, size, 8), 512)
+
+	b := make([]byte, size+step)
+	out, buf := b[:size], b[size:]
+
+	for i := 0; i < size; {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", fmt.Errorf("can't generate random string, %w", err)
+		}
+
+		for _, c := range buf {
+			if idx := int(c & mask); idx < len(alphabet) {
+				out[i] = alphabet[idx]
+				if i++; i == size {
+					break
+				}
+			}
+		}
+	}
+
+	return unsafe.String(unsafe.SliceData(out), size), nil
+}
+
+// validateAlphabet checks, if the given alphabet has from 2 to 128 unique ASCII
+// characters.
+func validateAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 128 {
+		return fmt.Errorf("error: alphabet must contain from 2 to 128 characters, but has %d", len(alphabet))
+	}
+
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return fmt.Errorf("error: alphabet must contain only ASCII characters, but has %q at position %d", c, i)
+		}
+		if seen[c] {
+			return fmt.Errorf("error: alphabet must contain unique characters, but %q is repeated", c)
+		}
+		seen[c] = true
+	}
+
+	return nil
+}
+
+// UUID represents a universally unique identifier (RFC 9562).
+type UUID [16]byte
+
+// ULID represents a universally unique lexicographically sortable identifier
+// (48 bits of the Unix time in milliseconds and 80 bits of the randomness).
+type ULID [16]byte
+
+// NewUUIDv4 generates a random UUID of the version 4 (RFC 9562) using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for an UUID and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.NewUUIDv4()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id) // like "0f8fad5b-d9cb-469f-a165-70867728950e"
+//	}
+func NewUUIDv4() (UUID, error) {
+	return defaultGenerator.NewUUIDv4()
+}
+
+// NewUUIDv7 generates a time-ordered UUID of the version 7 (RFC 9562) with the
+// current Unix time in milliseconds using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for an UUID and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.NewUUIDv7()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id) // like "018f3f9c-5a3e-7cc1-b4a4-6f1c2b3d4e5f"
+//	}
+func NewUUIDv7() (UUID, error) {
+	return defaultGenerator.NewUUIDv7()
+}
+
+// ParseUUID parses the UUID from the string in the canonical form (for ex.,
+// "0f8fad5b-d9cb-469f-a165-70867728950e"), with braces, with the "urn:uuid:"
+// prefix or without hyphens (case-insensitive).
+//
+// If err != nil returns zero-value for an UUID and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.ParseUUID("0f8fad5b-d9cb-469f-a165-70867728950e")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id.Version()) // 4
+//	}
+func ParseUUID(s string) (UUID, error) {
+	var id UUID
+
+	switch {
+	case len(s) == 36+9 && strings.EqualFold(s[:9], "urn:uuid:"):
+		s = s[9:]
+	case len(s) == 36+2 && s[0] == '{' && s[len(s)-1] == '}':
+		s = s[1 : len(s)-1]
+	}
+
+	switch len(s) {
+	case 32:
+		if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+			return UUID{}, fmt.Errorf("can't parse %q to UUID, %w", s, err)
+		}
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return UUID{}, fmt.Errorf("can't parse %q to UUID, invalid format", s)
+		}
+
+		var buf [32]byte
+		copy(buf[0:8], s[0:8])
+		copy(buf[8:12], s[9:13])
+		copy(buf[12:16], s[14:18])
+		copy(buf[16:20], s[19:23])
+		copy(buf[20:32], s[24:36])
+
+		if _, err := hex.Decode(id[:], buf[:]); err != nil {
+			return UUID{}, fmt.Errorf("can't parse %q to UUID, %w", s, err)
+		}
+	default:
+		return UUID{}, fmt.Errorf("can't parse %q to UUID, invalid length %d", s, len(s))
+	}
+
+	return id, nil
+}
+
+// IsValidUUID reports whether the string is a valid UUID (see ParseUUID) with
+// the RFC 9562 variant and a known version (from 1 to 8), or it's the Nil or
+// Max UUID.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		ok := gosl.IsValidUUID("0f8fad5b-d9cb-469f-a165-70867728950e")
+//
+//		fmt.Println(ok) // true
+//	}
+func IsValidUUID(s string) bool {
+	id, err := ParseUUID(s)
+	if err != nil {
+		return false
+	}
+
+	if id == (UUID{}) || id == maxUUID {
+		return true
 	}
 
-	b := make([]byte, bufferSize)
-	_, _ = rand.Read(b)
+	return id[8]&0xc0 == 0x80 && id.Version() >= 1 && id.Version() <= 8
+}
+
+// maxUUID represents the Max UUID (all bits are set to one).
+var maxUUID = UUID{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// Version returns the version of the UUID.
+func (id UUID) Version() int {
+	return int(id[6] >> 4)
+}
+
+// Time returns the time of the UUID of the version 7 with the millisecond
+// precision, otherwise returns zero-value for a time.Time.
+func (id UUID) Time() time.Time {
+	if id.Version() != 7 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(int64(uint48(id[:6])))
+}
+
+// String returns the UUID in the canonical form (for ex.,
+// "0f8fad5b-d9cb-469f-a165-70867728950e").
+func (id UUID) String() string {
+	b := id.appendString(make([]byte, 0, 36))
+
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id UUID) MarshalText() ([]byte, error) {
+	return id.appendString(make([]byte, 0, 36)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (id *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+
+	return nil
+}
+
+// appendString appends the UUID in the canonical form to the buffer.
+func (id UUID) appendString(b []byte) []byte {
+	b = hex.AppendEncode(b, id[0:4])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, id[4:6])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, id[6:8])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, id[8:10])
+	b = append(b, '-')
+
+	return hex.AppendEncode(b, id[10:16])
+}
+
+// NewULID generates a ULID with the current Unix time in milliseconds using
+// built-in "crypto/rand" package.
+//
+// ULIDs, generated within the same millisecond, are monotonically increasing
+// (the random part of the previous ULID is incremented by one).
+//
+// If err != nil returns zero-value for an ULID and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.NewULID()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id) // like "01HZX3Q4R5S6T7V8W9XAYBZC0D"
+//	}
+func NewULID() (ULID, error) {
+	return defaultGenerator.NewULID()
+}
+
+// ParseULID parses the ULID from the string in the Crockford's Base32 encoding
+// with 26 characters (case-insensitive).
+//
+// If err != nil returns zero-value for an ULID and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id.Time().UTC()) // 2016-07-30 23:54:10.259 +0000 UTC
+//	}
+func ParseULID(s string) (ULID, error) {
+	if len(s) != 26 {
+		return ULID{}, fmt.Errorf("can't parse %q to ULID, invalid length %d", s, len(s))
+	}
+
+	// The first character has only 3 bits (130 bits are encoded).
+	if v := crockfordValue(s[0]); v < 0 || v > 7 {
+		return ULID{}, fmt.Errorf("can't parse %q to ULID, %w", s, strconv.ErrRange)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordValue(s[i])
+		if v < 0 {
+			return ULID{}, fmt.Errorf("can't parse %q to ULID, invalid character %q at position %d", s, s[i], i)
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	var id ULID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+
+	return id, nil
+}
+
+// Time returns the time of the ULID with the millisecond precision.
+func (id ULID) Time() time.Time {
+	return time.UnixMilli(int64(uint48(id[:6])))
+}
+
+// String returns the ULID in the Crockford's Base32 encoding with 26
+// characters (for ex., "01ARZ3NDEKTSV4RRFFQ69G5FAV").
+func (id ULID) String() string {
+	b := id.appendString(make([]byte, 0, 26))
+
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id ULID) MarshalText() ([]byte, error) {
+	return id.appendString(make([]byte, 0, 26)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (id *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+
+	return nil
+}
+
+// appendString appends the ULID in the Crockford's Base32 encoding to the
+// buffer.
+func (id ULID) appendString(b []byte) []byte {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return append(b, buf[:]...)
+}
+
+// NewNanoID generates a NanoID (a random URL-safe string) with the given size
+// from the characters of the alphabet using built-in "crypto/rand" package.
+// If size is zero, uses 21 characters. If alphabet is empty, uses the
+// AlphabetURLSafe.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		id, err := gosl.NewNanoID(0, "")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id) // like "V1StGXR8_Z5jdHi6B-myT"
+//	}
+func NewNanoID(size int, alphabet string) (string, error) {
+	return defaultGenerator.NewNanoID(size, alphabet)
+}
+
+// PasswordPolicy represents a policy for the generated and user-supplied
+// passwords.
+type PasswordPolicy struct {
+	// MinLength sets the minimum length of the password (16 by default). The
+	// GeneratePassword function generates passwords of this length.
+	MinLength int
+
+	// MaxLength sets the maximum length of the password (no limit by default).
+	MaxLength int
+
+	// Lower, Upper, Digits and Symbols require at least one lowercase letter,
+	// uppercase letter, digit or symbol (one of "!#$%&*+-=?@^_~" for the
+	// generated passwords) in the password. If no classes are required, the
+	// GeneratePassword function uses letters and digits.
+	Lower, Upper, Digits, Symbols bool
+
+	// Exclude sets characters, which must not be in the password (for ex.,
+	// ambiguous "0O1lI").
+	Exclude string
+
+	// NoRepeats forbids identical consecutive characters (for ex., "aa").
+	NoRepeats bool
+}
+
+// Character classes of the passwords.
+const (
+	passwordLower   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits  = "0123456789"
+	passwordSymbols = "!#$%&*+-=?@^_~"
+)
+
+// GeneratePassword generates a random password, which satisfies the given
+// policy, using built-in "crypto/rand" package. Each password, which satisfies
+// the policy, has the same probability.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		p, err := gosl.GeneratePassword(gosl.PasswordPolicy{
+//			MinLength: 20,
+//			Lower:     true,
+//			Upper:     true,
+//			Digits:    true,
+//			Symbols:   true,
+//			Exclude:   "0O1lI",
+//			NoRepeats: true,
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(p)
+//	}
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	return defaultGenerator.GeneratePassword(policy)
+}
+
+// ValidatePassword checks, if the given user-supplied password satisfies the
+// policy. Letters and digits of all languages are accepted, other characters
+// (except spaces) are counted as symbols.
+//
+// If the password doesn't satisfy the policy, returns error with all
+// violations.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		policy := gosl.PasswordPolicy{MinLength: 12, Upper: true, Digits: true}
+//
+//		if err := gosl.ValidatePassword("password", policy); err != nil {
+//			fmt.Println(err)
+//		}
+//	}
+func ValidatePassword(password string, policy PasswordPolicy) error {
+	var errs []error
+
+	length := utf8.RuneCountInString(password)
+	if minLength := policy.minLength(); length < minLength {
+		errs = append(errs, fmt.Errorf("password must contain at least %d characters, but has %d", minLength, length))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		errs = append(errs, fmt.Errorf("password must contain at most %d characters, but has %d", policy.MaxLength, length))
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasRepeat bool
+	var excluded []rune
+	prev := rune(-1)
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsSpace(r) && !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+
+		if strings.ContainsRune(policy.Exclude, r) && !slices.Contains(excluded, r) {
+			excluded = append(excluded, r)
+		}
+
+		hasRepeat = hasRepeat || r == prev
+		prev = r
+	}
+
+	for _, class := range []struct {
+		required, has bool
+		name          string
+	}{
+		{policy.Lower, hasLower, "lowercase letter"},
+		{policy.Upper, hasUpper, "uppercase letter"},
+		{policy.Digits, hasDigit, "digit"},
+		{policy.Symbols, hasSymbol, "symbol"},
+	} {
+		if class.required && !class.has {
+			errs = append(errs, fmt.Errorf("password must contain at least one %s", class.name))
+		}
+	}
+
+	if len(excluded) > 0 {
+		errs = append(errs, fmt.Errorf("password must not contain characters %q", string(excluded)))
+	}
+	if policy.NoRepeats && hasRepeat {
+		errs = append(errs, errors.New("password must not contain identical consecutive characters"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EstimateEntropy estimates the entropy of the given password in bits (by the
+// size of the used character classes and the length, where repeated and
+// sequential characters, like "aaa" or "123", are counted as one bit) and
+// returns it with the strength score from 0 (very weak, < 28 bits) to 4 (very
+// strong, >= 128 bits).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		bits, score := gosl.EstimateEntropy("correct-Horse-battery-staple-42")
+//
+//		fmt.Printf("%.1f bits, score %d\n", bits, score)
+//	}
+func EstimateEntropy(s string) (bits float64, score int) {
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r < utf8.RuneSelf:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+
+	// Size of the character pool by the classes of the characters.
+	pool := 0
+	for _, class := range []struct {
+		has  bool
+		size int
+	}{
+		{hasLower, 26}, {hasUpper, 26}, {hasDigit, 10}, {hasSymbol, 33}, {hasOther, 100},
+	} {
+		if class.has {
+			pool += class.size
+		}
+	}
+
+	if pool == 0 {
+		return 0, 0
+	}
+
+	perChar := math.Log2(float64(pool))
+	prev := rune(-1)
+
+	for _, r := range s {
+		if d := r - prev; d >= -1 && d <= 1 {
+			bits++ // repeated or sequential character
+		} else {
+			bits += perChar
+		}
+		prev = r
+	}
+
+	switch {
+	case bits < 28:
+		return bits, 0
+	case bits < 36:
+		return bits, 1
+	case bits < 60:
+		return bits, 2
+	case bits < 128:
+		return bits, 3
+	default:
+		return bits, 4
+	}
+}
+
+// GenerateToken generates a random API token with the given prefix (for ex.,
+// "myapp_pat"), the Base62 body with the given number of random bytes (32 by
+// default, at least 16) and the CRC32 checksum of the prefix and body in the
+// last 6 characters (for ex., "myapp_pat_<body><checksum>") using built-in
+// "crypto/rand" package.
+//
+// Tokens with prefixes can be detected by secret scanners, and the checksum
+// allows to verify the format offline (see VerifyTokenFormat).
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		token, err := gosl.GenerateToken("myapp_pat", 0)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(token) // like "myapp_pat_4kT9...Zx01aB"
+//	}
+func GenerateToken(prefix string, entropyBytes int) (string, error) {
+	return defaultGenerator.GenerateToken(prefix, entropyBytes)
+}
+
+// VerifyTokenFormat checks, if the given token has the prefix, the Base62 body
+// and the valid checksum (see GenerateToken) without a database lookup.
+//
+// If the token is invalid, returns error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		if err := gosl.VerifyTokenFormat("myapp_pat_wrong", "myapp_pat"); err != nil {
+//			fmt.Println(err)
+//		}
+//	}
+func VerifyTokenFormat(token, prefix string) error {
+	if err := validateTokenPrefix(prefix); err != nil {
+		return err
+	}
+
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+	if !strings.HasPrefix(token, prefix) {
+		return fmt.Errorf("invalid token, prefix %q is missing", prefix)
+	}
+
+	body := token[len(prefix):]
+	if len(body) < tokenMinBodyLen+tokenChecksumLen {
+		return fmt.Errorf("invalid token, body is too short (%d characters)", len(body))
+	}
+
+	for i := 0; i < len(body); i++ {
+		if strings.IndexByte(AlphabetBase62, body[i]) < 0 {
+			return fmt.Errorf("invalid token, invalid character %q at position %d", body[i], len(prefix)+i)
+		}
+	}
+
+	payload := token[:len(token)-tokenChecksumLen]
+	if tokenChecksum(payload) != token[len(payload):] {
+		return errors.New("invalid token, checksum mismatch")
+	}
+
+	return nil
+}
+
+// RandomInt generates a random integer of the type T in the range from min to
+// max (inclusive) using built-in "crypto/rand" package (without the modulo
+// bias).
+//
+// If err != nil returns zero-value for the type T and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		n, err := gosl.RandomInt(1, 6)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(n) // from 1 to 6
+//	}
+func RandomInt[T Integer](min, max T) (T, error) {
+	return RandomIntWith(defaultGenerator, min, max)
+}
+
+// RandomChoice returns a random element of the given slice using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for the type T and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.RandomChoice([]string{"rock", "paper", "scissors"})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s)
+//	}
+func RandomChoice[T any](s []T) (T, error) {
+	return RandomChoiceWith(defaultGenerator, s)
+}
+
+// RandomSample returns n random elements of the given slice (without
+// repetitions of the same elements) in a random order using built-in
+// "crypto/rand" package. The given slice is not modified.
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.RandomSample([]int{1, 2, 3, 4, 5}, 3)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // like [4 1 5]
+//	}
+func RandomSample[T any](s []T, n int) ([]T, error) {
+	return RandomSampleWith(defaultGenerator, s, n)
+}
+
+// Shuffle shuffles elements of the given slice in place using built-in
+// "crypto/rand" package (each permutation has the same probability).
+//
+// If err != nil returns error (the slice can be partially shuffled).
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := []int{1, 2, 3, 4, 5}
+//
+//		if err := gosl.Shuffle(s); err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // like [3 5 1 2 4]
+//	}
+func Shuffle[T any](s []T) error {
+	return ShuffleWith(defaultGenerator, s)
+}
+
+// WeightedChoice returns a random element of the given slice with the
+// probability, proportional to its weight, using built-in "crypto/rand"
+// package. Weights must be non-negative with the positive sum.
+//
+// If err != nil returns zero-value for the type T and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.WeightedChoice([]string{"common", "rare"}, []float64{9, 1})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // "common" in 90% of cases
+//	}
+func WeightedChoice[T any](s []T, weights []float64) (T, error) {
+	return WeightedChoiceWith(defaultGenerator, s, weights)
+}
+
+// newUUIDv4 helps to generate the UUID of the version 4 with the given source
+// of randomness.
+func newUUIDv4(r io.Reader) (UUID, error) {
+	var id UUID
+	if _, err := io.ReadFull(r, id[:]); err != nil {
+		return UUID{}, fmt.Errorf("can't generate UUID, %w", err)
+	}
+
+	id[6] = id[6]&0x0f | 0x40 // version 4
+	id[8] = id[8]&0x3f | 0x80 // variant of the RFC 9562
+
+	return id, nil
+}
+
+// newUUIDv7 helps to generate the UUID of the version 7 with the given source
+// of randomness and time.
+func newUUIDv7(r io.Reader, now time.Time) (UUID, error) {
+	var id UUID
+	if _, err := io.ReadFull(r, id[6:]); err != nil {
+		return UUID{}, fmt.Errorf("can't generate UUID, %w", err)
+	}
+
+	putUint48(id[:6], uint64(now.UnixMilli()))
+
+	id[6] = id[6]&0x0f | 0x70 // version 7
+	id[8] = id[8]&0x3f | 0x80 // variant of the RFC 9562
+
+	return id, nil
+}
+
+// newNanoID helps to generate the NanoID with the given source of randomness.
+func newNanoID(r io.Reader, size int, alphabet string) (string, error) {
+	if size == 0 {
+		size = 21
+	}
+	if alphabet == "" {
+		alphabet = AlphabetURLSafe
+	}
+
+	return randomStringFrom(r, size, alphabet)
+}
+
+// minLength returns the minimum length of the password.
+func (p *PasswordPolicy) minLength() int {
+	if p.MinLength <= 0 {
+		return 16
+	}
+
+	return p.MinLength
+}
+
+// generatePassword helps to generate the password with the given source of
+// randomness for the GeneratePassword function.
+func generatePassword(r io.Reader, policy PasswordPolicy) (string, error) {
+	length := policy.minLength()
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		return "", fmt.Errorf("error: minimum length (%d) of the password is greater than maximum length (%d)", length, policy.MaxLength)
+	}
+
+	// Collect the required classes without the excluded characters.
+	exclude := func(r rune) rune {
+		if strings.ContainsRune(policy.Exclude, r) {
+			return -1
+		}
+		return r
+	}
+
+	var required []string
+	for _, class := range []struct {
+		required bool
+		chars    string
+	}{
+		{policy.Lower, passwordLower},
+		{policy.Upper, passwordUpper},
+		{policy.Digits, passwordDigits},
+		{policy.Symbols, passwordSymbols},
+	} {
+		if !class.required {
+			continue
+		}
+
+		chars := strings.Map(exclude, class.chars)
+		if chars == "" {
+			return "", fmt.Errorf("error: all characters of the required class (%s) are excluded", class.chars)
+		}
+		required = append(required, chars)
+	}
+
+	pool := strings.Join(required, "")
+	if len(required) == 0 {
+		pool = strings.Map(exclude, passwordLower+passwordUpper+passwordDigits)
+	}
+
+	if len(required) > length {
+		return "", fmt.Errorf("error: length (%d) of the password is less than number of the required classes (%d)", length, len(required))
+	}
+	if len(pool) < 2 {
+		return "", errors.New("error: password must be generated from at least 2 characters")
+	}
+
+	b := make([]byte, length)
+
+	// Generate passwords until the one with all required classes (it gives
+	// the same probability for each valid password).
+	for attempt := 0; attempt < 1000; attempt++ {
+		for i := 0; i < length; {
+			chars, err := randomStringFrom(r, length-i+8, pool)
+			if err != nil {
+				return "", fmt.Errorf("can't generate password, %w", err)
+			}
+
+			for j := 0; j < len(chars) && i < length; j++ {
+				if policy.NoRepeats && i > 0 && chars[j] == b[i-1] {
+					continue
+				}
+				b[i] = chars[j]
+				i++
+			}
+		}
+
+		if hasAllClasses(b, required) {
+			return unsafe.String(unsafe.SliceData(b), length), nil
+		}
+	}
+
+	return "", errors.New("error: can't generate password, which satisfies the policy, try to increase length")
+}
+
+// hasAllClasses reports whether the password has at least one character of
+// each class.
+func hasAllClasses(password []byte, classes []string) bool {
+	for _, chars := range classes {
+		if !bytes.ContainsAny(password, chars) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Lengths of the parts of the API tokens.
+const (
+	tokenChecksumLen = 6  // 62^6 > 2^32
+	tokenMinBodyLen  = 22 // 16 random bytes
+)
+
+// generateToken helps to generate the API token with the given source of
+// randomness for the GenerateToken function.
+func generateToken(r io.Reader, prefix string, entropyBytes int) (string, error) {
+	if err := validateTokenPrefix(prefix); err != nil {
+		return "", err
+	}
+
+	if entropyBytes == 0 {
+		entropyBytes = 32
+	}
+	if entropyBytes < 16 {
+		return "", fmt.Errorf("error: token must have at least 16 random bytes, but has %d", entropyBytes)
+	}
+
+	// Number of the Base62 characters with the same entropy as the given
+	// number of random bytes.
+	size := int(math.Ceil(float64(entropyBytes*8) / math.Log2(62)))
+
+	body, err := randomStringFrom(r, size, AlphabetBase62)
+	if err != nil {
+		return "", fmt.Errorf("can't generate token, %w", err)
+	}
+
+	payload := Concat(strings.TrimSuffix(prefix, "_"), "_", body)
+
+	return Concat(payload, tokenChecksum(payload)), nil
+}
+
+// validateTokenPrefix checks, if the given prefix of the API token is not
+// empty and has only ASCII letters, digits and underscores.
+func validateTokenPrefix(prefix string) error {
+	if strings.TrimSuffix(prefix, "_") == "" {
+		return errors.New("error: prefix of the token is empty")
+	}
+
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; c != '_' && strings.IndexByte(AlphabetBase62, c) < 0 {
+			return fmt.Errorf("error: prefix of the token has invalid character %q, use only letters, digits and underscores", c)
+		}
+	}
+
+	return nil
+}
+
+// tokenChecksum returns the CRC32 checksum of the payload in the Base62
+// encoding, padded with zeros to 6 characters.
+func tokenChecksum(payload string) string {
+	sum := EncodeUint64Base62(uint64(crc32.ChecksumIEEE(stringToBytes(payload))))
+
+	return strings.Repeat("0", tokenChecksumLen-len(sum)) + sum
+}
+
+// randomUint64n returns a uniform random number in [0, n) with the given
+// source of randomness (without the modulo bias, by the Lemire's method).
+func randomUint64n(r io.Reader, n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, errors.New("error: upper bound of the random number must be greater than zero")
+	}
+
+	var b [8]byte
+
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, fmt.Errorf("can't generate random number, %w", err)
+		}
+
+		hi, lo := bits.Mul64(binary.LittleEndian.Uint64(b[:]), n)
+		if lo >= n || lo >= -n%n {
+			return hi, nil
+		}
+	}
+}
+
+// randomFloat64 returns a uniform random number in [0, 1) with the given
+// source of randomness.
+func randomFloat64(r io.Reader) (float64, error) {
+	n, err := randomUint64n(r, 1<<53)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(n) / (1 << 53), nil
+}
+
+// randomInt helps to generate the random integer with the given source of
+// randomness for the RandomInt function.
+func randomInt[T Integer](r io.Reader, min, max T) (T, error) {
+	if min > max {
+		return 0, fmt.Errorf("error: min (%d) is greater than max (%d)", min, max)
+	}
+
+	// Size of the range in the two's complement arithmetic (works for both
+	// signed and unsigned types).
+	span := uint64(max) - uint64(min)
+
+	if span == math.MaxUint64 {
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, fmt.Errorf("can't generate random number, %w", err)
+		}
+
+		return T(binary.LittleEndian.Uint64(b[:])), nil
+	}
+
+	n, err := randomUint64n(r, span+1)
+	if err != nil {
+		return 0, err
+	}
+
+	return min + T(n), nil
+}
+
+// randomChoice helps to select the random element with the given source of
+// randomness for the RandomChoice function.
+func randomChoice[T any](r io.Reader, s []T) (T, error) {
+	var zero T
+
+	if len(s) == 0 {
+		return zero, errors.New("error: can't choose from empty slice")
+	}
+
+	i, err := randomUint64n(r, uint64(len(s)))
+	if err != nil {
+		return zero, err
+	}
+
+	return s[i], nil
+}
+
+// randomSample helps to select the random elements with the given source of
+// randomness for the RandomSample function.
+func randomSample[T any](r io.Reader, s []T, n int) ([]T, error) {
+	if n < 0 || n > len(s) {
+		return nil, fmt.Errorf("error: sample size must be from 0 to %d, but is %d", len(s), n)
+	}
+
+	// Partial Fisher-Yates shuffle of the copy.
+	sample := slices.Clone(s)
+	for i := 0; i < n; i++ {
+		j, err := randomUint64n(r, uint64(len(sample)-i))
+		if err != nil {
+			return nil, err
+		}
+
+		sample[i], sample[i+int(j)] = sample[i+int(j)], sample[i]
+	}
+
+	return sample[:n:n], nil
+}
+
+// shuffle helps to shuffle the slice with the given source of randomness for
+// the Shuffle function.
+func shuffle[T any](r io.Reader, s []T) error {
+	// Fisher-Yates shuffle.
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := randomUint64n(r, uint64(i+1))
+		if err != nil {
+			return err
+		}
+
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return nil
+}
+
+// weightedChoice helps to select the random element by weights with the given
+// source of randomness for the WeightedChoice function.
+func weightedChoice[T any](r io.Reader, s []T, weights []float64) (T, error) {
+	var zero T
+
+	if len(s) == 0 {
+		return zero, errors.New("error: can't choose from empty slice")
+	}
+	if len(s) != len(weights) {
+		return zero, fmt.Errorf("error: number of weights (%d) is not equal to number of elements (%d)", len(weights), len(s))
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return zero, fmt.Errorf("error: weight %v of element #%d must be non-negative and finite", w, i)
+		}
+		total += w
+	}
+
+	if total <= 0 || math.IsInf(total, 0) {
+		return zero, errors.New("error: sum of the weights must be positive and finite")
+	}
+
+	f, err := randomFloat64(r)
+	if err != nil {
+		return zero, err
+	}
+
+	target := f * total
+	last := 0
+
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if target < w {
+			return s[i], nil
+		}
+
+		target -= w
+		last = i
+	}
+
+	return s[last], nil // rounding errors of the floating-point numbers
+}
+
+// ulidGenerator represents a generator of the monotonic ULIDs, which is safe
+// for concurrent use by multiple goroutines.
+type ulidGenerator struct {
+	mu   sync.Mutex
+	last ULID
+}
+
+// next generates the next ULID with the given source of randomness and time.
+// If the time is the same as (or before, if the clock goes back) the time of
+// the previous ULID, increments the random part of the previous ULID.
+func (g *ulidGenerator) next(r io.Reader, now time.Time) (ULID, error) {
+	ms := uint64(now.UnixMilli())
+	if ms >= 1<<48 {
+		return ULID{}, fmt.Errorf("can't generate ULID, time %s is out of range", now)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if last := uint48(g.last[:6]); ms <= last && last != 0 {
+		// Increment the random part (80 bits) of the previous ULID.
+		id := g.last
+		for i := len(id) - 1; i >= 6; i-- {
+			id[i]++
+			if id[i] != 0 {
+				g.last = id
+				return id, nil
+			}
+		}
+
+		return ULID{}, errors.New("can't generate ULID, random part overflow within the same millisecond")
+	}
+
+	var id ULID
+	if _, err := io.ReadFull(r, id[6:]); err != nil {
+		return ULID{}, fmt.Errorf("can't generate ULID, %w", err)
+	}
+
+	putUint48(id[:6], ms)
+	g.last = id
+
+	return id, nil
+}
+
+// crockfordValue returns the value of the character in the Crockford's Base32
+// alphabet (case-insensitive, with aliases), otherwise returns -1.
+func crockfordValue(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch c {
+	case 'I', 'L':
+		c = '1'
+	case 'O':
+		c = '0'
+	}
+
+	return strings.IndexByte(crockfordAlphabet, c)
+}
+
+// uint48 returns the big-endian 48-bit number from the first 6 bytes of b.
+func uint48(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
 
-	return hex.EncodeToString(b)[:size], nil
+// putUint48 puts the 48-bit number to the first 6 bytes of b in the big-endian
+// order.
+func putUint48(b []byte, v uint64) {
+	b[0], b[1], b[2] = byte(v>>40), byte(v>>32), byte(v>>24)
+	b[3], b[4], b[5] = byte(v>>16), byte(v>>8), byte(v)
 }
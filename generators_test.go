@@ -1,8 +1,18 @@
 package gosl
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,6 +58,38 @@ func BenchmarkRandomString_Size4096(b *testing.B) {
 	resultGenerators = r
 }
 
+func BenchmarkRandomStringFrom_Size8(b *testing.B) {
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = RandomStringFrom(8, AlphabetBase62)
+	}
+	resultGenerators = r
+}
+
+func BenchmarkRandomStringFrom_Size64(b *testing.B) {
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = RandomStringFrom(64, AlphabetBase62)
+	}
+	resultGenerators = r
+}
+
+func BenchmarkRandomStringFrom_Size512(b *testing.B) {
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = RandomStringFrom(512, AlphabetBase62)
+	}
+	resultGenerators = r
+}
+
+func BenchmarkRandomStringFrom_Size4096(b *testing.B) {
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = RandomStringFrom(4096, AlphabetBase62)
+	}
+	resultGenerators = r
+}
+
 func TestRandomString(t *testing.T) {
 	_, err := RandomString(-1)
 	require.Error(t, err)
@@ -55,8 +97,15 @@ func TestRandomString(t *testing.T) {
 	_, err = RandomString(0)
 	require.Error(t, err)
 
-	_, err = RandomString(8)
-	require.NoError(t, err)
+	for _, size := range []int{1, 2, 3, 8, 33, 4096} {
+		s, err := RandomString(size)
+		require.NoError(t, err)
+		assert.Len(t, s, size)
+		assert.Empty(t, strings.Trim(s, AlphabetHex), "string %s", s)
+	}
+
+	_, err = randomString(iotest.ErrReader(errors.New("failed")), 8)
+	require.Error(t, err)
 
 	g := Utility{} // tests for method
 
@@ -69,3 +118,966 @@ func TestRandomString(t *testing.T) {
 	_, err = g.RandomString(8)
 	require.NoError(t, err)
 }
+
+func TestRandomStringFrom(t *testing.T) {
+	_, err := RandomStringFrom(0, AlphabetBase62)
+	require.Error(t, err)
+
+	for _, alphabet := range []string{"", "a", "aba", "abcы", strings.Repeat("a", 129)} {
+		_, err = RandomStringFrom(8, alphabet)
+		require.Error(t, err, "alphabet %s", alphabet)
+	}
+
+	for _, alphabet := range []string{
+		AlphabetHex, AlphabetAlphanumeric, AlphabetBase62, AlphabetURLSafe, AlphabetHumanFriendly, "01",
+	} {
+		require.NoError(t, validateAlphabet(alphabet))
+
+		for _, size := range []int{1, 8, 64, 4096} {
+			s, err := RandomStringFrom(size, alphabet)
+			require.NoError(t, err)
+			assert.Len(t, s, size)
+			assert.Empty(t, strings.Trim(s, alphabet), "string %s", s)
+		}
+	}
+
+	assert.NotContains(t, AlphabetHumanFriendly, "0")
+	assert.NotContains(t, AlphabetHumanFriendly, "O")
+	assert.NotContains(t, AlphabetHumanFriendly, "1")
+	assert.NotContains(t, AlphabetHumanFriendly, "l")
+
+	// Bytes out of the alphabet (after the mask) are rejected.
+	s, err := randomStringFrom(bytes.NewReader([]byte{0, 1, 2, 3, 7, 4, 0, 0}), 4, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abca", s)
+
+	_, err = randomStringFrom(iotest.ErrReader(errors.New("failed")), 8, AlphabetBase62)
+	require.Error(t, err)
+
+	_, err = randomStringFrom(bytes.NewReader([]byte{3, 3, 3}), 8, "abc")
+	require.Error(t, err)
+
+	// Characters have the same probability (no modulo bias).
+	s, err = RandomStringFrom(60000, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMN") // 40 characters
+	require.NoError(t, err)
+
+	for _, c := range "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMN" {
+		assert.InDelta(t, 1500, strings.Count(s, string(c)), 200, "character %c", c)
+	}
+
+	g := Utility{} // tests for method
+
+	_, err = g.RandomStringFrom(0, AlphabetBase62)
+	require.Error(t, err)
+
+	s, err = g.RandomStringFrom(8, AlphabetHumanFriendly)
+	require.NoError(t, err)
+	assert.Len(t, s, 8)
+}
+
+func BenchmarkNewUUIDv4(b *testing.B) {
+	var r UUID
+	for i := 0; i < b.N; i++ {
+		r, _ = NewUUIDv4()
+	}
+	resultGenerators = r.String()
+}
+
+func BenchmarkNewULID(b *testing.B) {
+	var r ULID
+	for i := 0; i < b.N; i++ {
+		r, _ = NewULID()
+	}
+	resultGenerators = r.String()
+}
+
+func TestNewUUIDv4(t *testing.T) {
+	seen := map[UUID]bool{}
+
+	for i := 0; i < 100; i++ {
+		id, err := NewUUIDv4()
+		require.NoError(t, err)
+		assert.Equal(t, 4, id.Version())
+		assert.EqualValues(t, 0x80, id[8]&0xc0)
+		assert.True(t, IsValidUUID(id.String()))
+		assert.True(t, id.Time().IsZero())
+		assert.False(t, seen[id])
+		seen[id] = true
+
+		parsed, err := ParseUUID(id.String())
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+	}
+
+	_, err := newUUIDv4(iotest.ErrReader(errors.New("failed")))
+	require.Error(t, err)
+
+	id, err := newUUIDv4(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)))
+	require.NoError(t, err)
+	assert.Equal(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", id.String())
+
+	g := Utility{} // tests for method
+
+	id, err = g.NewUUIDv4()
+	require.NoError(t, err)
+	assert.Equal(t, 4, id.Version())
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	now := time.UnixMilli(1645557742000) // the example of the RFC 9562
+
+	id, err := newUUIDv7(bytes.NewReader(make([]byte, 10)), now)
+	require.NoError(t, err)
+	assert.Equal(t, "017f22e2-79b0-7000-8000-000000000000", id.String())
+	assert.Equal(t, 7, id.Version())
+	assert.True(t, now.Equal(id.Time()))
+
+	_, err = newUUIDv7(iotest.ErrReader(errors.New("failed")), now)
+	require.Error(t, err)
+
+	// UUIDs of the version 7 are sorted by the time.
+	prev, err := newUUIDv7(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)), now)
+	require.NoError(t, err)
+
+	next, err := newUUIDv7(bytes.NewReader(make([]byte, 10)), now.Add(time.Millisecond))
+	require.NoError(t, err)
+	assert.Less(t, prev.String(), next.String())
+
+	id, err = NewUUIDv7()
+	require.NoError(t, err)
+	assert.Equal(t, 7, id.Version())
+	assert.True(t, IsValidUUID(id.String()))
+	assert.WithinDuration(t, time.Now(), id.Time(), time.Minute)
+
+	g := Utility{} // tests for method
+
+	id, err = g.NewUUIDv7()
+	require.NoError(t, err)
+	assert.Equal(t, 7, id.Version())
+}
+
+func TestParseUUID(t *testing.T) {
+	expected := UUID{
+		0x0f, 0x8f, 0xad, 0x5b, 0xd9, 0xcb, 0x46, 0x9f,
+		0xa1, 0x65, 0x70, 0x86, 0x77, 0x28, 0x95, 0x0e,
+	}
+
+	for _, s := range []string{
+		"0f8fad5b-d9cb-469f-a165-70867728950e",
+		"0F8FAD5B-D9CB-469F-A165-70867728950E",
+		"{0f8fad5b-d9cb-469f-a165-70867728950e}",
+		"urn:uuid:0f8fad5b-d9cb-469f-a165-70867728950e",
+		"0f8fad5bd9cb469fa16570867728950e",
+	} {
+		id, err := ParseUUID(s)
+		require.NoError(t, err, "string %s", s)
+		assert.Equal(t, expected, id, "string %s", s)
+		assert.True(t, IsValidUUID(s), "string %s", s)
+	}
+
+	for _, s := range []string{
+		"",
+		"0f8fad5b-d9cb-469f-a165-70867728950",
+		"0f8fad5b-d9cb-469f-a165-70867728950x",
+		"0f8fad5bd-9cb-469f-a165-70867728950e",
+		"{0f8fad5b-d9cb-469f-a165-70867728950e",
+		"0f8fad5bd9cb469fa16570867728950g",
+	} {
+		_, err := ParseUUID(s)
+		require.Error(t, err, "string %s", s)
+		assert.False(t, IsValidUUID(s), "string %s", s)
+	}
+
+	// Nil and Max UUIDs are valid, but unknown versions and variants are not.
+	assert.True(t, IsValidUUID("00000000-0000-0000-0000-000000000000"))
+	assert.True(t, IsValidUUID("ffffffff-ffff-ffff-ffff-ffffffffffff"))
+	assert.False(t, IsValidUUID("0f8fad5b-d9cb-069f-a165-70867728950e"))
+	assert.False(t, IsValidUUID("0f8fad5b-d9cb-469f-c165-70867728950e"))
+
+	// Encoding to the text.
+	data, err := json.Marshal(map[string]UUID{"id": expected})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"0f8fad5b-d9cb-469f-a165-70867728950e"}`, string(data))
+
+	var decoded map[string]UUID
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, expected, decoded["id"])
+
+	require.Error(t, json.Unmarshal([]byte(`{"id":"wrong"}`), &decoded))
+
+	g := Utility{} // tests for method
+
+	id, err := g.ParseUUID("0f8fad5b-d9cb-469f-a165-70867728950e")
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	assert.True(t, g.IsValidUUID("0f8fad5b-d9cb-469f-a165-70867728950e"))
+}
+
+func TestNewULID(t *testing.T) {
+	now := time.UnixMilli(1469922850259)
+	gen := &ulidGenerator{}
+
+	id, err := gen.next(bytes.NewReader(make([]byte, 10)), now)
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEK0000000000000000", id.String())
+	assert.True(t, now.Equal(id.Time()))
+
+	// Monotonic ULIDs within the same millisecond (and if the clock goes back).
+	next, err := gen.next(iotest.ErrReader(errors.New("failed")), now)
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEK0000000000000001", next.String())
+
+	next, err = gen.next(iotest.ErrReader(errors.New("failed")), now.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEK0000000000000002", next.String())
+
+	// New random part for the next millisecond.
+	next, err = gen.next(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)), now.Add(time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEMZZZZZZZZZZZZZZZZ", next.String())
+
+	_, err = gen.next(bytes.NewReader(nil), now.Add(time.Millisecond))
+	require.Error(t, err) // overflow of the random part
+
+	_, err = gen.next(iotest.ErrReader(errors.New("failed")), now.Add(time.Second))
+	require.Error(t, err)
+
+	_, err = gen.next(bytes.NewReader(make([]byte, 10)), time.UnixMilli(1<<48))
+	require.Error(t, err)
+
+	// Concurrent generation of the unique and sorted ULIDs.
+	const goroutines, count = 8, 1000
+
+	results := make(chan []ULID, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			ids := make([]ULID, 0, count)
+			for j := 0; j < count; j++ {
+				id, err := NewULID()
+				if err != nil {
+					break
+				}
+				ids = append(ids, id)
+			}
+			results <- ids
+		}()
+	}
+
+	seen := map[ULID]bool{}
+	for i := 0; i < goroutines; i++ {
+		ids := <-results
+		require.Len(t, ids, count)
+
+		for j, id := range ids {
+			assert.False(t, seen[id])
+			seen[id] = true
+
+			if j > 0 {
+				assert.Less(t, ids[j-1].String(), id.String())
+			}
+		}
+	}
+
+	g := Utility{} // tests for method
+
+	id, err = g.NewULID()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), id.Time(), time.Minute)
+}
+
+func TestParseULID(t *testing.T) {
+	id, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.String())
+	assert.EqualValues(t, 1469922850259, id.Time().UnixMilli())
+
+	lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	require.NoError(t, err)
+	assert.Equal(t, id, lower)
+
+	aliases, err := ParseULID("OLARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, id, aliases)
+
+	id, err = ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	require.NoError(t, err)
+	assert.Equal(t, ULID(maxUUID), id)
+
+	_, err = ParseULID("80000000000000000000000000")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "01ARZ3NDEKTSV4RRFFQ69G5FA*"} {
+		_, err = ParseULID(s)
+		require.Error(t, err, "string %s", s)
+	}
+
+	// Encoding to the text.
+	data, err := json.Marshal(map[string]ULID{"id": id})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"7ZZZZZZZZZZZZZZZZZZZZZZZZZ"}`, string(data))
+
+	var decoded map[string]ULID
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, id, decoded["id"])
+
+	require.Error(t, json.Unmarshal([]byte(`{"id":"wrong"}`), &decoded))
+
+	g := Utility{} // tests for method
+
+	id, err = g.ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.String())
+}
+
+func TestNewNanoID(t *testing.T) {
+	id, err := NewNanoID(0, "")
+	require.NoError(t, err)
+	assert.Len(t, id, 21)
+	assert.Empty(t, strings.Trim(id, AlphabetURLSafe))
+
+	id, err = NewNanoID(10, "0123456789")
+	require.NoError(t, err)
+	assert.Len(t, id, 10)
+	assert.Empty(t, strings.Trim(id, "0123456789"))
+
+	_, err = NewNanoID(-1, "")
+	require.Error(t, err)
+
+	_, err = NewNanoID(10, "a")
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	id, err = g.NewNanoID(0, "")
+	require.NoError(t, err)
+	assert.Len(t, id, 21)
+}
+
+func TestGenerator(t *testing.T) {
+	now := func() time.Time { return time.UnixMilli(1469922850259) }
+
+	g1 := NewGenerator(NewSeededReader(42), GeneratorOptions{Now: now})
+	g2 := NewGenerator(NewSeededReader(42), GeneratorOptions{Now: now})
+	g3 := NewGenerator(NewSeededReader(43), GeneratorOptions{Now: now})
+
+	// The same seed gives the same results.
+	for _, gen := range []func(g *Generator) (string, error){
+		func(g *Generator) (string, error) { return g.RandomString(16) },
+		func(g *Generator) (string, error) { return g.RandomStringFrom(16, AlphabetHumanFriendly) },
+		func(g *Generator) (string, error) { return g.NewNanoID(0, "") },
+		func(g *Generator) (string, error) {
+			id, err := g.NewUUIDv4()
+			return id.String(), err
+		},
+		func(g *Generator) (string, error) {
+			id, err := g.NewUUIDv7()
+			return id.String(), err
+		},
+		func(g *Generator) (string, error) {
+			id, err := g.NewULID()
+			return id.String(), err
+		},
+	} {
+		s1, err := gen(g1)
+		require.NoError(t, err)
+
+		s2, err := gen(g2)
+		require.NoError(t, err)
+
+		s3, err := gen(g3)
+		require.NoError(t, err)
+
+		assert.Equal(t, s1, s2)
+		assert.NotEqual(t, s1, s3)
+	}
+
+	id, err := g1.NewULID()
+	require.NoError(t, err)
+	assert.True(t, now().Equal(id.Time()))
+
+	// Errors of the source are propagated.
+	failing := NewGenerator(iotest.ErrReader(errors.New("failed")))
+
+	_, err = failing.RandomString(8)
+	require.Error(t, err)
+
+	_, err = failing.NewUUIDv4()
+	require.Error(t, err)
+
+	_, err = failing.NewULID()
+	require.Error(t, err)
+
+	// The "crypto/rand" reader by default.
+	s, err := NewGenerator(nil).RandomString(8)
+	require.NoError(t, err)
+	assert.Len(t, s, 8)
+
+	// Concurrent use with the deterministic source.
+	const goroutines = 8
+
+	done := make(chan []ULID, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			ids := make([]ULID, 0, 100)
+			for j := 0; j < 100; j++ {
+				if _, err := g1.RandomString(8); err != nil {
+					break
+				}
+				id, err := g1.NewULID()
+				if err != nil {
+					break
+				}
+				ids = append(ids, id)
+			}
+			done <- ids
+		}()
+	}
+
+	seen := map[ULID]bool{}
+	for i := 0; i < goroutines; i++ {
+		ids := <-done
+		require.Len(t, ids, 100)
+
+		for _, id := range ids {
+			assert.False(t, seen[id])
+			seen[id] = true
+		}
+	}
+
+	g := Utility{} // tests for method
+
+	s1, err := g.NewGenerator(g.NewSeededReader(42)).RandomString(16)
+	require.NoError(t, err)
+
+	s2, err := NewGenerator(NewSeededReader(42)).RandomString(16)
+	require.NoError(t, err)
+	assert.Equal(t, s1, s2)
+}
+
+func BenchmarkGeneratePassword(b *testing.B) {
+	policy := PasswordPolicy{MinLength: 20, Lower: true, Upper: true, Digits: true, Symbols: true}
+
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = GeneratePassword(policy)
+	}
+	resultGenerators = r
+}
+
+func TestGeneratePassword(t *testing.T) {
+	p, err := GeneratePassword(PasswordPolicy{})
+	require.NoError(t, err)
+	assert.Len(t, p, 16)
+	assert.Empty(t, strings.Trim(p, AlphabetBase62))
+	require.NoError(t, ValidatePassword(p, PasswordPolicy{}))
+
+	policies := []PasswordPolicy{
+		{MinLength: 4, Lower: true, Upper: true, Digits: true, Symbols: true},
+		{MinLength: 20, Lower: true, Upper: true, Digits: true, Symbols: true, Exclude: "0O1lI", NoRepeats: true},
+		{MinLength: 64, Digits: true, NoRepeats: true},
+		{MinLength: 8, Upper: true, Exclude: "ABCDEFGHIJKLMNOPQRSTUVWX"},
+		{MinLength: 8, MaxLength: 8, Symbols: true},
+	}
+
+	for _, policy := range policies {
+		for i := 0; i < 50; i++ {
+			p, err := GeneratePassword(policy)
+			require.NoError(t, err, "policy %+v", policy)
+			assert.Len(t, p, policy.MinLength)
+			require.NoError(t, ValidatePassword(p, policy), "password %s", p)
+		}
+	}
+
+	for _, policy := range []PasswordPolicy{
+		{MinLength: 3, Lower: true, Upper: true, Digits: true, Symbols: true},
+		{MinLength: 10, MaxLength: 8},
+		{Digits: true, Exclude: "0123456789"},
+		{Digits: true, Exclude: "012345678", NoRepeats: true},
+	} {
+		_, err := GeneratePassword(policy)
+		require.Error(t, err, "policy %+v", policy)
+	}
+
+	_, err = NewGenerator(iotest.ErrReader(errors.New("failed"))).GeneratePassword(PasswordPolicy{})
+	require.Error(t, err)
+
+	// The same seed gives the same password.
+	p1, err := NewGenerator(NewSeededReader(1)).GeneratePassword(policies[1])
+	require.NoError(t, err)
+
+	p2, err := NewGenerator(NewSeededReader(1)).GeneratePassword(policies[1])
+	require.NoError(t, err)
+	assert.Equal(t, p1, p2)
+
+	g := Utility{} // tests for method
+
+	p, err = g.GeneratePassword(policies[0])
+	require.NoError(t, err)
+	assert.Len(t, p, 4)
+}
+
+func TestValidatePassword(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength: 8, MaxLength: 16, Lower: true, Upper: true, Digits: true, Symbols: true,
+		Exclude: "0O", NoRepeats: true,
+	}
+
+	require.NoError(t, ValidatePassword("Secret-42x", policy))
+	require.NoError(t, ValidatePassword("Пароль-42Ё!", policy)) // letters of all languages
+
+	for password, expected := range map[string]string{
+		"Sec-4x":             "at least 8 characters",
+		"Secret-42x-Secret-": "at most 16 characters",
+		"SECRET-42X":         "lowercase letter",
+		"secret-42x":         "uppercase letter",
+		"Secret-xyz":         "digit",
+		"Secret42x":          "symbol",
+		"Secret-0O2x":        `characters "0O"`,
+		"Secret--42x":        "identical consecutive characters",
+	} {
+		err := ValidatePassword(password, policy)
+		require.Error(t, err, "password %s", password)
+		assert.Contains(t, err.Error(), expected, "password %s", password)
+	}
+
+	// All violations are returned.
+	err := ValidatePassword("aa", policy)
+	require.Error(t, err)
+	assert.Len(t, strings.Split(err.Error(), "\n"), 5)
+
+	require.Error(t, ValidatePassword("", PasswordPolicy{}))
+
+	g := Utility{} // tests for method
+
+	require.NoError(t, g.ValidatePassword("Secret-42x", policy))
+}
+
+func TestEstimateEntropy(t *testing.T) {
+	bits, score := EstimateEntropy("")
+	assert.Zero(t, bits)
+	assert.Zero(t, score)
+
+	bits, score = EstimateEntropy("abcdefgh")
+	assert.InDelta(t, 4.7+7, bits, 0.1) // only the first character is random
+	assert.Equal(t, 0, score)
+
+	bits, score = EstimateEntropy("aaaaaaaaaaaaaaaaaaaaaaaa")
+	assert.Less(t, bits, 28.0)
+	assert.Equal(t, 0, score)
+
+	bits, score = EstimateEntropy("qwpzkxmd")
+	assert.InDelta(t, 8*math.Log2(26), bits, 0.01)
+	assert.Equal(t, 2, score)
+
+	_, score = EstimateEntropy("Tr0ub4dor&3")
+	assert.Equal(t, 3, score)
+
+	_, score = EstimateEntropy("correct-Horse-battery-staple-42")
+	assert.Equal(t, 4, score)
+
+	// Generated passwords are strong.
+	p, err := GeneratePassword(PasswordPolicy{MinLength: 20, Lower: true, Upper: true, Digits: true, Symbols: true})
+	require.NoError(t, err)
+
+	_, score = EstimateEntropy(p)
+	assert.GreaterOrEqual(t, score, 3)
+
+	// Non-ASCII characters increase the pool.
+	bitsASCII, _ := EstimateEntropy("qwpzkxmd")
+	bitsOther, _ := EstimateEntropy("qwpzkxmж")
+	assert.Greater(t, bitsOther, bitsASCII)
+
+	g := Utility{} // tests for method
+
+	bits, score = g.EstimateEntropy("qwpzkxmd")
+	assert.InDelta(t, 8*math.Log2(26), bits, 0.01)
+	assert.Equal(t, 2, score)
+}
+
+func TestGenerateToken(t *testing.T) {
+	token, err := GenerateToken("myapp_pat", 0)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "myapp_pat_"))
+	assert.Len(t, token, len("myapp_pat_")+43+6) // 32 bytes in 43 characters
+	require.NoError(t, VerifyTokenFormat(token, "myapp_pat"))
+	require.NoError(t, VerifyTokenFormat(token, "myapp_pat_"))
+
+	token, err = GenerateToken("ghp_", 16)
+	require.NoError(t, err)
+	assert.Len(t, token, len("ghp_")+22+6)
+	require.NoError(t, VerifyTokenFormat(token, "ghp"))
+
+	for _, prefix := range []string{"", "_", "my-app", "мой"} {
+		_, err = GenerateToken(prefix, 0)
+		require.Error(t, err, "prefix %s", prefix)
+
+		require.Error(t, VerifyTokenFormat(token, prefix), "prefix %s", prefix)
+	}
+
+	_, err = GenerateToken("myapp", 15)
+	require.Error(t, err)
+
+	_, err = NewGenerator(iotest.ErrReader(errors.New("failed"))).GenerateToken("myapp", 0)
+	require.Error(t, err)
+
+	// The same seed gives the same token.
+	token, err = NewGenerator(NewSeededReader(1)).GenerateToken("myapp_pat", 0)
+	require.NoError(t, err)
+
+	same, err := NewGenerator(NewSeededReader(1)).GenerateToken("myapp_pat", 0)
+	require.NoError(t, err)
+	assert.Equal(t, token, same)
+
+	// Any change of the token is detected.
+	body := token[len("myapp_pat_"):]
+
+	for name, invalid := range map[string]string{
+		"other prefix":     "other_pat_" + body,
+		"short body":       "myapp_pat_" + body[:10],
+		"invalid char":     "myapp_pat_" + body[:5] + "-" + body[6:],
+		"changed body":     "myapp_pat_" + swapFirstChar(body),
+		"changed checksum": token[:len(token)-1] + swapFirstChar(token[len(token)-1:]),
+		"checksum of body": "myapp_pat_" + body[:len(body)-6] + tokenChecksum(body[:len(body)-6]),
+	} {
+		require.Error(t, VerifyTokenFormat(invalid, "myapp_pat"), "case %s", name)
+	}
+
+	g := Utility{} // tests for method
+
+	token, err = g.GenerateToken("myapp_pat", 0)
+	require.NoError(t, err)
+	require.NoError(t, g.VerifyTokenFormat(token, "myapp_pat"))
+}
+
+// swapFirstChar replaces the first character of the Base62 string with another
+// one.
+func swapFirstChar(s string) string {
+	if s[0] == 'a' {
+		return "b" + s[1:]
+	}
+
+	return "a" + s[1:]
+}
+
+func BenchmarkRandomInt(b *testing.B) {
+	var r int
+	for i := 0; i < b.N; i++ {
+		r, _ = RandomInt(1, 100)
+	}
+	resultGenerators = strconv.Itoa(r)
+}
+
+func BenchmarkShuffle_100(b *testing.B) {
+	s := make([]int, 100)
+
+	for i := 0; i < b.N; i++ {
+		_ = Shuffle(s)
+	}
+}
+
+// chiSquare returns the chi-squared statistic of the observed counts for the
+// expected probabilities.
+func chiSquare(counts []int, probabilities []float64, total int) float64 {
+	stat := 0.0
+	for i, count := range counts {
+		expected := probabilities[i] * float64(total)
+		stat += (float64(count) - expected) * (float64(count) - expected) / expected
+	}
+
+	return stat
+}
+
+// uniform returns n equal probabilities.
+func uniform(n int) []float64 {
+	p := make([]float64, n)
+	for i := range p {
+		p[i] = 1 / float64(n)
+	}
+
+	return p
+}
+
+func TestRandomInt(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		n, err := RandomInt(-3, 3)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, n, -3)
+		assert.LessOrEqual(t, n, 3)
+
+		u, err := RandomInt[uint8](250, 255)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, u, uint8(250))
+	}
+
+	n, err := RandomInt(7, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	// Full ranges of the types.
+	_, err = RandomInt[int64](math.MinInt64, math.MaxInt64)
+	require.NoError(t, err)
+
+	_, err = RandomInt[uint64](0, math.MaxUint64)
+	require.NoError(t, err)
+
+	i8, err := RandomInt[int8](math.MinInt8, math.MaxInt8)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, i8, int8(math.MinInt8))
+
+	_, err = RandomInt(2, 1)
+	require.Error(t, err)
+
+	_, err = RandomIntWith(NewGenerator(iotest.ErrReader(errors.New("failed"))), 1, 6)
+	require.Error(t, err)
+
+	_, err = RandomIntWith[uint64](NewGenerator(iotest.ErrReader(errors.New("failed"))), 0, math.MaxUint64)
+	require.Error(t, err)
+
+	// Uniform distribution (chi-squared test with 5 degrees of freedom and the
+	// critical value for p = 0.001).
+	gen := NewGenerator(NewSeededReader(1))
+	counts := make([]int, 6)
+
+	const total = 60000
+	for i := 0; i < total; i++ {
+		n, err := RandomIntWith(gen, 1, 6)
+		require.NoError(t, err)
+		counts[n-1]++
+	}
+	assert.Less(t, chiSquare(counts, uniform(6), total), 20.52, "counts %v", counts)
+
+	// Also for a range, which isn't a power of two, near the limit of the type.
+	counts = make([]int, 3)
+	for i := 0; i < total; i++ {
+		n, err := RandomIntWith[int8](gen, 125, 127)
+		require.NoError(t, err)
+		counts[n-125]++
+	}
+	assert.Less(t, chiSquare(counts, uniform(3), total), 13.82, "counts %v", counts)
+}
+
+func TestRandomChoice(t *testing.T) {
+	s := []string{"rock", "paper", "scissors"}
+
+	c, err := RandomChoice(s)
+	require.NoError(t, err)
+	assert.Contains(t, s, c)
+
+	_, err = RandomChoice([]int{})
+	require.Error(t, err)
+
+	_, err = RandomChoiceWith(NewGenerator(iotest.ErrReader(errors.New("failed"))), s)
+	require.Error(t, err)
+
+	// Uniform distribution.
+	gen := NewGenerator(NewSeededReader(2))
+	counts := make([]int, 10)
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	const total = 50000
+	for i := 0; i < total; i++ {
+		n, err := RandomChoiceWith(gen, items)
+		require.NoError(t, err)
+		counts[n]++
+	}
+	assert.Less(t, chiSquare(counts, uniform(10), total), 27.88, "counts %v", counts)
+
+	g := GenericUtility[string, any]{} // tests for method
+
+	c, err = g.RandomChoice(s)
+	require.NoError(t, err)
+	assert.Contains(t, s, c)
+}
+
+func TestRandomSample(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+
+	sample, err := RandomSample(s, 3)
+	require.NoError(t, err)
+	assert.Len(t, sample, 3)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s) // not modified
+	assert.Subset(t, s, sample)
+
+	seen := map[int]bool{}
+	for _, v := range sample {
+		assert.False(t, seen[v])
+		seen[v] = true
+	}
+
+	sample, err = RandomSample(s, 0)
+	require.NoError(t, err)
+	assert.Empty(t, sample)
+
+	sample, err = RandomSample(s, 5)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, s, sample)
+
+	_, err = RandomSample(s, 6)
+	require.Error(t, err)
+
+	_, err = RandomSample(s, -1)
+	require.Error(t, err)
+
+	_, err = RandomSampleWith(NewGenerator(iotest.ErrReader(errors.New("failed"))), s, 2)
+	require.Error(t, err)
+
+	// Each element is in the sample with the same probability.
+	gen := NewGenerator(NewSeededReader(3))
+	counts := make([]int, 5)
+
+	const total = 20000
+	for i := 0; i < total; i++ {
+		sample, err := RandomSampleWith(gen, s, 2)
+		require.NoError(t, err)
+		for _, v := range sample {
+			counts[v-1]++
+		}
+	}
+	assert.Less(t, chiSquare(counts, uniform(5), total*2), 18.47, "counts %v", counts)
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	sample, err = g.RandomSample(s, 2)
+	require.NoError(t, err)
+	assert.Len(t, sample, 2)
+}
+
+func TestShuffle(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	require.NoError(t, Shuffle(s))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s)
+
+	require.NoError(t, Shuffle([]int{}))
+	require.NoError(t, Shuffle([]int{1}))
+
+	require.Error(t, ShuffleWith(NewGenerator(iotest.ErrReader(errors.New("failed"))), s))
+
+	// Each of 6 permutations of 3 elements has the same probability.
+	gen := NewGenerator(NewSeededReader(4))
+	counts := map[[3]int]int{}
+
+	const total = 60000
+	for i := 0; i < total; i++ {
+		p := []int{1, 2, 3}
+		require.NoError(t, ShuffleWith(gen, p))
+		counts[[3]int(p)]++
+	}
+	require.Len(t, counts, 6)
+
+	observed := make([]int, 0, len(counts))
+	for _, count := range counts {
+		observed = append(observed, count)
+	}
+	assert.Less(t, chiSquare(observed, uniform(6), total), 20.52, "counts %v", counts)
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	require.NoError(t, g.Shuffle(s))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s)
+}
+
+func TestWeightedChoice(t *testing.T) {
+	s := []string{"common", "rare", "never"}
+
+	c, err := WeightedChoice(s, []float64{9, 1, 0})
+	require.NoError(t, err)
+	assert.Contains(t, []string{"common", "rare"}, c)
+
+	for _, weights := range [][]float64{
+		nil,
+		{1, 2},
+		{1, -1, 1},
+		{0, 0, 0},
+		{1, math.NaN(), 1},
+		{1, math.Inf(1), 1},
+		{math.MaxFloat64, math.MaxFloat64, 0},
+	} {
+		_, err = WeightedChoice(s, weights)
+		require.Error(t, err, "weights %v", weights)
+	}
+
+	_, err = WeightedChoice([]string{}, []float64{})
+	require.Error(t, err)
+
+	_, err = WeightedChoiceWith(NewGenerator(iotest.ErrReader(errors.New("failed"))), s, []float64{1, 1, 1})
+	require.Error(t, err)
+
+	// Distribution by the weights.
+	gen := NewGenerator(NewSeededReader(5))
+	weights := []float64{6, 3, 1, 0}
+	counts := make([]int, 4)
+
+	const total = 50000
+	for i := 0; i < total; i++ {
+		n, err := WeightedChoiceWith(gen, []int{0, 1, 2, 3}, weights)
+		require.NoError(t, err)
+		counts[n]++
+	}
+	assert.Zero(t, counts[3])
+	assert.Less(t, chiSquare(counts[:3], []float64{0.6, 0.3, 0.1}, total), 13.82, "counts %v", counts)
+
+	g := GenericUtility[string, any]{} // tests for method
+
+	c, err = g.WeightedChoice(s, []float64{0, 1, 0})
+	require.NoError(t, err)
+	assert.Equal(t, "rare", c)
+}
+
+func TestRandomWith_Seeded(t *testing.T) {
+	// random returns values of all functions with the generator of the given
+	// seed.
+	random := func(seed uint64) []any {
+		gen := NewGenerator(NewSeededReader(seed))
+		s := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		n, err := RandomIntWith(gen, 1, 1000)
+		require.NoError(t, err)
+
+		c, err := RandomChoiceWith(gen, s)
+		require.NoError(t, err)
+
+		sample, err := RandomSampleWith(gen, s, 5)
+		require.NoError(t, err)
+
+		shuffled := slices.Clone(s)
+		require.NoError(t, ShuffleWith(gen, shuffled))
+
+		w, err := WeightedChoiceWith(gen, s, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		require.NoError(t, err)
+
+		return []any{n, c, sample, shuffled, w}
+	}
+
+	assert.Equal(t, random(42), random(42))
+	assert.NotEqual(t, random(42), random(43))
+
+	// Nil generator uses the "crypto/rand" package.
+	n, err := RandomIntWith[int](nil, 1, 6)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 1)
+	assert.LessOrEqual(t, n, 6)
+
+	g := GenericUtility[int, any]{} // tests for method
+
+	s := []int{1, 2, 3}
+
+	c1, err := g.RandomChoiceWith(NewGenerator(NewSeededReader(1)), s)
+	require.NoError(t, err)
+
+	c2, err := RandomChoiceWith(NewGenerator(NewSeededReader(1)), s)
+	require.NoError(t, err)
+	assert.Equal(t, c2, c1)
+
+	sample, err := g.RandomSampleWith(NewGenerator(NewSeededReader(1)), s, 2)
+	require.NoError(t, err)
+	assert.Len(t, sample, 2)
+
+	require.NoError(t, g.ShuffleWith(NewGenerator(NewSeededReader(1)), s))
+	assert.ElementsMatch(t, []int{1, 2, 3}, s)
+
+	w, err := g.WeightedChoiceWith(NewGenerator(NewSeededReader(1)), s, []float64{0, 0, 1})
+	require.NoError(t, err)
+	assert.Equal(t, s[2], w)
+}
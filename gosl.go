@@ -3,7 +3,12 @@
 // performance.
 package gosl
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"io"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Utility represents struct for a regular function.
 type Utility struct{}
@@ -12,6 +17,26 @@ type Utility struct{}
 // generic function.
 type GenericUtility[T any, K comparable] struct{}
 
+// Signed represents a constraint for the signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned represents a constraint for the unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer represents a constraint for the integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float represents a constraint for the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
 // Concat concatenate strings using the built-in copy and "unsafe" package with
 // unsafe.String function.
 //
@@ -20,6 +45,63 @@ func (u *Utility) Concat(s ...string) string {
 	return Concat(s...)
 }
 
+// ConcatWithSep concatenate strings with the given separator between them
+// using the built-in copy and "unsafe" package with unsafe.String function.
+//
+// If s has no elements returns zero-value for a string.
+func (u *Utility) ConcatWithSep(sep string, s ...string) string {
+	return ConcatWithSep(sep, s...)
+}
+
+// JoinQuoted concatenate double-quoted (with Go escape sequences) strings with
+// the given separator between them using the built-in "strconv" and "unsafe"
+// packages.
+//
+// If s has no elements returns zero-value for a string.
+func (u *Utility) JoinQuoted(sep string, s ...string) string {
+	return JoinQuoted(sep, s...)
+}
+
+// ConcatBytes concatenate byte slices to the one byte slice using the
+// built-in copy function with a single allocation.
+//
+// If parts has no elements returns zero-value for a byte slice.
+func (u *Utility) ConcatBytes(parts ...[]byte) []byte {
+	return ConcatBytes(parts...)
+}
+
+// AppendConcat appends strings to the given byte slice dst and returns the
+// extended byte slice. If dst has not enough capacity, it grows with a single
+// allocation.
+func (u *Utility) AppendConcat(dst []byte, s ...string) []byte {
+	return AppendConcat(dst, s...)
+}
+
+// WriteConcat concatenate strings and writes them to the given io.Writer with
+// a single allocation and a single call of the Write method.
+//
+// If err != nil returns the number of bytes written and error.
+func (u *Utility) WriteConcat(w io.Writer, s ...string) (int, error) {
+	return WriteConcat(w, s...)
+}
+
+// Interpolate replaces the named placeholders (for ex., "{name}") in the given
+// pattern with the values. Placeholders can contain a format verb of the "fmt"
+// package after the colon (for ex., "{price:%.2f}").
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) Interpolate(pattern string, values map[string]any, opts ...TemplateOptions) (string, error) {
+	return Interpolate(pattern, values, opts...)
+}
+
+// CompileTemplate parses the given pattern with the named placeholders to the
+// Template, which can be rendered many times.
+//
+// If err != nil returns nil and error.
+func (u *Utility) CompileTemplate(pattern string, opts ...TemplateOptions) (*Template, error) {
+	return CompileTemplate(pattern, opts...)
+}
+
 // ContainsCaseInsensitive reports if substr is within s string using built-in
 // "strings" package with strings.Contains. Case-insensitive for input values by
 // default.
@@ -47,6 +129,191 @@ func (u *Utility) RandomString(size int) (string, error) {
 	return RandomString(size)
 }
 
+// NewGenerator creates a new Generator with the given source of randomness. If
+// r is nil, uses the reader of the built-in "crypto/rand" package.
+func (u *Utility) NewGenerator(r io.Reader, opts ...GeneratorOptions) *Generator {
+	return NewGenerator(r, opts...)
+}
+
+// NewSeededReader creates a new deterministic source of randomness with the
+// given seed for tests (must not be used for secrets).
+func (u *Utility) NewSeededReader(seed uint64) io.Reader {
+	return NewSeededReader(seed)
+}
+
+// RandomStringFrom generates a random string with a given size from the
+// characters of the alphabet (for ex., AlphabetHumanFriendly) using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) RandomStringFrom(size int, alphabet string) (string, error) {
+	return RandomStringFrom(size, alphabet)
+}
+
+// NewUUIDv4 generates a random UUID of the version 4 (RFC 9562) using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for an UUID and error.
+func (u *Utility) NewUUIDv4() (UUID, error) {
+	return NewUUIDv4()
+}
+
+// NewUUIDv7 generates a time-ordered UUID of the version 7 (RFC 9562) with the
+// current Unix time in milliseconds using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for an UUID and error.
+func (u *Utility) NewUUIDv7() (UUID, error) {
+	return NewUUIDv7()
+}
+
+// ParseUUID parses the UUID from the string in the canonical form, with
+// braces, with the "urn:uuid:" prefix or without hyphens (case-insensitive).
+//
+// If err != nil returns zero-value for an UUID and error.
+func (u *Utility) ParseUUID(s string) (UUID, error) {
+	return ParseUUID(s)
+}
+
+// IsValidUUID reports whether the string is a valid UUID with the RFC 9562
+// variant and a known version, or it's the Nil or Max UUID.
+func (u *Utility) IsValidUUID(s string) bool {
+	return IsValidUUID(s)
+}
+
+// NewULID generates a monotonic ULID with the current Unix time in
+// milliseconds using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for an ULID and error.
+func (u *Utility) NewULID() (ULID, error) {
+	return NewULID()
+}
+
+// ParseULID parses the ULID from the string in the Crockford's Base32 encoding
+// with 26 characters (case-insensitive).
+//
+// If err != nil returns zero-value for an ULID and error.
+func (u *Utility) ParseULID(s string) (ULID, error) {
+	return ParseULID(s)
+}
+
+// NewNanoID generates a NanoID with the given size (21 by default) from the
+// characters of the alphabet (AlphabetURLSafe by default) using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) NewNanoID(size int, alphabet string) (string, error) {
+	return NewNanoID(size, alphabet)
+}
+
+// GeneratePassword generates a random password, which satisfies the given
+// policy, using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GeneratePassword(policy PasswordPolicy) (string, error) {
+	return GeneratePassword(policy)
+}
+
+// ValidatePassword checks, if the given user-supplied password satisfies the
+// policy.
+//
+// If the password doesn't satisfy the policy, returns error with all
+// violations.
+func (u *Utility) ValidatePassword(password string, policy PasswordPolicy) error {
+	return ValidatePassword(password, policy)
+}
+
+// EstimateEntropy estimates the entropy of the given password in bits and
+// returns it with the strength score from 0 (very weak) to 4 (very strong).
+func (u *Utility) EstimateEntropy(s string) (bits float64, score int) {
+	return EstimateEntropy(s)
+}
+
+// GenerateToken generates a random API token with the given prefix, the Base62
+// body with the given number of random bytes (32 by default, at least 16) and
+// the CRC32 checksum using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GenerateToken(prefix string, entropyBytes int) (string, error) {
+	return GenerateToken(prefix, entropyBytes)
+}
+
+// VerifyTokenFormat checks, if the given token has the prefix, the Base62 body
+// and the valid checksum without a database lookup.
+//
+// If the token is invalid, returns error.
+func (u *Utility) VerifyTokenFormat(token, prefix string) error {
+	return VerifyTokenFormat(token, prefix)
+}
+
+// GenerateOTPSecret generates a random secret for the one-time passwords with
+// the given number of bytes (20 by default) in the Base32 encoding without
+// padding using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GenerateOTPSecret(size int) (string, error) {
+	return GenerateOTPSecret(size)
+}
+
+// GenerateHOTP generates the counter-based one-time password (RFC 4226) with
+// the given secret in the Base32 encoding.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GenerateHOTP(secret string, counter uint64, opts ...OTPOptions) (string, error) {
+	return GenerateHOTP(secret, counter, opts...)
+}
+
+// ValidateHOTP checks, if the given code is the valid counter-based one-time
+// password (RFC 4226) for the counter or one of the next counters within the
+// skew window. Returns the next counter (after the matched one).
+//
+// If err != nil returns zero-value for an uint64, false and error.
+func (u *Utility) ValidateHOTP(code, secret string, counter uint64, opts ...OTPOptions) (next uint64, ok bool, err error) {
+	return ValidateHOTP(code, secret, counter, opts...)
+}
+
+// GenerateTOTP generates the time-based one-time password (RFC 6238) for the
+// given time with the secret in the Base32 encoding.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GenerateTOTP(secret string, t time.Time, opts ...OTPOptions) (string, error) {
+	return GenerateTOTP(secret, t, opts...)
+}
+
+// ValidateTOTP checks, if the given code is the valid time-based one-time
+// password (RFC 6238) for the given time or one of the time steps within the
+// skew window.
+//
+// If err != nil returns false and error.
+func (u *Utility) ValidateTOTP(code, secret string, t time.Time, opts ...OTPOptions) (bool, error) {
+	return ValidateTOTP(code, secret, t, opts...)
+}
+
+// TOTPAuthURI returns the otpauth:// provisioning URI of the time-based
+// one-time passwords with the given secret, issuer and account name.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) TOTPAuthURI(secret, issuer, account string, opts ...OTPOptions) (string, error) {
+	return TOTPAuthURI(secret, issuer, account, opts...)
+}
+
+// HOTPAuthURI returns the otpauth:// provisioning URI of the counter-based
+// one-time passwords with the given secret, issuer, account name and initial
+// counter.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) HOTPAuthURI(secret, issuer, account string, counter uint64, opts ...OTPOptions) (string, error) {
+	return HOTPAuthURI(secret, issuer, account, counter, opts...)
+}
+
+// NewSnowflake creates a new Snowflake generator of the sortable 64-bit IDs
+// with the given node ID, which must be unique for each instance of the
+// service.
+//
+// If err != nil returns nil and error.
+func (u *Utility) NewSnowflake(node int64, opts ...SnowflakeOptions) (*Snowflake, error) {
+	return NewSnowflake(node, opts...)
+}
+
 // RenderStyled render a styled string with a given lipgloss.Style template
 // using "charmbracelet/lipgloss" package.
 //
@@ -55,6 +322,23 @@ func (u *Utility) RenderStyled(s string, template lipgloss.Style) string {
 	return RenderStyled(s, template)
 }
 
+// RenderJSONDiff render a list of the changes from the DiffJSON (or
+// DiffValues) function to the colored human-readable string using
+// "charmbracelet/lipgloss" package.
+//
+// If changes has no elements returns zero-value for a string.
+func (u *Utility) RenderJSONDiff(changes []JSONChange) string {
+	return RenderJSONDiff(changes)
+}
+
+// DiffJSON compares two JSON documents and returns a list of the changes
+// (added, removed and modified values with JSON Pointer paths) from a to b.
+//
+// If err != nil returns zero-value for a slice and error.
+func (u *Utility) DiffJSON(a, b []byte, opts ...DiffOptions) ([]JSONChange, error) {
+	return DiffJSON(a, b, opts...)
+}
+
 // ToBytes converts string to byte slice using the built-in "unsafe" package
 // with unsafe.Slice function.
 //
@@ -71,12 +355,177 @@ func (u *Utility) ToString(b []byte) (string, error) {
 	return ToString(b)
 }
 
+// ParseBool parses a bool from the given string. Accepts "1", "t", "true",
+// "y", "yes", "on" as true and "0", "f", "false", "n", "no", "off" as false
+// (case-insensitive).
+//
+// If err != nil returns false and error.
+func (u *Utility) ParseBool(s string) (bool, error) {
+	return ParseBool(s)
+}
+
+// ParseByteSize parses a size in bytes from the given human-readable string
+// (for ex., "512MiB", "1.5 GB" or "1024") with units in the SI and IEC
+// standards.
+//
+// If err != nil returns zero-value for a ByteSize and error.
+func (u *Utility) ParseByteSize(s string) (ByteSize, error) {
+	return ParseByteSize(s)
+}
+
+// FormatByteSize formats the given size in bytes to the human-readable string
+// with the largest unit (for ex., "1.5GiB"). If iec is true, uses units in the
+// IEC standard, otherwise in the SI standard.
+func (u *Utility) FormatByteSize(size ByteSize, iec bool) string {
+	return FormatByteSize(size, iec)
+}
+
+// ParseDuration parses a duration from the given string like the
+// time.ParseDuration function does, but supports days ("d") and weeks ("w")
+// units too (for ex., "2d4h").
+//
+// If err != nil returns zero-value for a time.Duration and error.
+func (u *Utility) ParseDuration(s string) (time.Duration, error) {
+	return ParseDuration(s)
+}
+
+// EncodeBase58 encodes the given byte slice to the Base58 string with the
+// Bitcoin alphabet.
+func (u *Utility) EncodeBase58(b []byte) string {
+	return EncodeBase58(b)
+}
+
+// DecodeBase58 decodes the given Base58 string with the Bitcoin alphabet to
+// the byte slice.
+//
+// If err != nil returns nil and error.
+func (u *Utility) DecodeBase58(s string) ([]byte, error) {
+	return DecodeBase58(s)
+}
+
+// EncodeBase62 encodes the given byte slice (as a big-endian integer) to the
+// Base62 string with the "0-9A-Za-z" alphabet.
+func (u *Utility) EncodeBase62(b []byte) string {
+	return EncodeBase62(b)
+}
+
+// DecodeBase62 decodes the given Base62 string with the "0-9A-Za-z" alphabet
+// to the byte slice.
+//
+// If err != nil returns nil and error.
+func (u *Utility) DecodeBase62(s string) ([]byte, error) {
+	return DecodeBase62(s)
+}
+
+// EncodeUint64Base62 encodes the given number to the Base62 string with the
+// "0-9A-Za-z" alphabet.
+func (u *Utility) EncodeUint64Base62(n uint64) string {
+	return EncodeUint64Base62(n)
+}
+
+// DecodeUint64Base62 decodes the given Base62 string with the "0-9A-Za-z"
+// alphabet to the number.
+//
+// If err != nil returns zero-value for an uint64 and error.
+func (u *Utility) DecodeUint64Base62(s string) (uint64, error) {
+	return DecodeUint64Base62(s)
+}
+
+// EncodeBase32Crockford encodes the given byte slice to the Crockford's Base32
+// string (without padding).
+func (u *Utility) EncodeBase32Crockford(b []byte) string {
+	return EncodeBase32Crockford(b)
+}
+
+// DecodeBase32Crockford decodes the given Crockford's Base32 string to the
+// byte slice (case-insensitive, hyphens are ignored).
+//
+// If err != nil returns nil and error.
+func (u *Utility) DecodeBase32Crockford(s string) ([]byte, error) {
+	return DecodeBase32Crockford(s)
+}
+
 // ModifyByValue modify an unknown key in the given map[string]any by it value.
 // Supports nested maps, but only if their type is map[string]any.
 func (u *Utility) ModifyByValue(m map[string]any, foundValue, newValue any) (foundKey bool, results map[string]any) {
 	return ModifyByValue(m, foundValue, newValue)
 }
 
+// ToSnakeCase converts the given string to the snake_case (for ex.,
+// "HTTPServerURL" to "http_server_url") with zero or one allocation.
+func (u *Utility) ToSnakeCase(s string) string {
+	return ToSnakeCase(s)
+}
+
+// ToKebabCase converts the given string to the kebab-case (for ex.,
+// "HTTPServerURL" to "http-server-url") with zero or one allocation.
+func (u *Utility) ToKebabCase(s string) string {
+	return ToKebabCase(s)
+}
+
+// ToScreamingSnake converts the given string to the SCREAMING_SNAKE_CASE (for
+// ex., "HTTPServerURL" to "HTTP_SERVER_URL") with zero or one allocation.
+func (u *Utility) ToScreamingSnake(s string) string {
+	return ToScreamingSnake(s)
+}
+
+// ToCamelCase converts the given string to the camelCase (for ex.,
+// "http_server_url" to "httpServerUrl") with zero or one allocation.
+func (u *Utility) ToCamelCase(s string) string {
+	return ToCamelCase(s)
+}
+
+// ToPascalCase converts the given string to the PascalCase (for ex.,
+// "http_server_url" to "HttpServerUrl") with zero or one allocation.
+func (u *Utility) ToPascalCase(s string) string {
+	return ToPascalCase(s)
+}
+
+// Slugify converts the given string to the URL slug with the transliteration
+// of the common Latin-extended and Cyrillic letters to the ASCII ones.
+func (u *Utility) Slugify(s string, opts ...SlugOptions) string {
+	return Slugify(s, opts...)
+}
+
+// SanitizeFilename removes characters, which are invalid in file names on the
+// common filesystems (Windows, macOS and Linux).
+//
+// If the sanitized name is empty, returns zero-value for a string.
+func (u *Utility) SanitizeFilename(name string) string {
+	return SanitizeFilename(name)
+}
+
+// GenerateStruct generates Go struct definitions (with "json" and "koanf"
+// tags) from the given sample documents in the JSON, YAML or TOML format.
+//
+// If err != nil returns zero-value for a string and error.
+func (u *Utility) GenerateStruct(name, fileFormat string, samples ...[]byte) (string, error) {
+	return GenerateStruct(name, fileFormat, samples...)
+}
+
+// ParseJSONValue parses JSON data (byte slice) to the dynamic *JSONValue.
+//
+// If err != nil returns zero-value for a *JSONValue and error.
+func (u *Utility) ParseJSONValue(data []byte) (*JSONValue, error) {
+	return ParseJSONValue(data)
+}
+
+// NewJSONValue converts the given Go value to the dynamic *JSONValue, using
+// the same rules as the Marshal function.
+//
+// If err != nil returns zero-value for a *JSONValue and error.
+func (u *Utility) NewJSONValue(value any) (*JSONValue, error) {
+	return NewJSONValue(value)
+}
+
+// JoinFunc converts items of type T to strings with the given function and
+// concatenate them with the given separator between them.
+//
+// If items has no elements returns zero-value for a string.
+func (g *GenericUtility[T, K]) JoinFunc(items []T, sep string, fn func(T) string) string {
+	return JoinFunc(items, sep, fn)
+}
+
 // ContainsInSlice reports if value T is within slice []T.
 //
 // If s have a zero-value returns false for a bool.
@@ -101,6 +550,120 @@ func (g *GenericUtility[T, K]) NotEquals(value1, value2 K) bool {
 	return NotEquals(value1, value2)
 }
 
+// DiffValues compares JSON representations of two values of type *T and
+// returns a list of the changes from a to b.
+//
+// If err != nil returns zero-value for a slice and error.
+func (g *GenericUtility[T, K]) DiffValues(a, b *T, opts ...DiffOptions) ([]JSONChange, error) {
+	return DiffValues(a, b, opts...)
+}
+
+// Cast converts the given value of any type (for ex., from the map[string]any
+// of the ModifyByValue function or the JSON document) to the type T.
+//
+// If err != nil returns zero-value for the type T and error.
+func (g *GenericUtility[T, K]) Cast(v any) (T, error) {
+	return Cast[T](v)
+}
+
+// MustCast converts the given value of any type to the type T (see Cast).
+//
+// If the value can't be converted, MustCast panics.
+func (g *GenericUtility[T, K]) MustCast(v any) T {
+	return MustCast[T](v)
+}
+
+// StructToMap converts the given struct *T to the map[string]any, using names
+// from the given tag (for ex., "koanf" or "json").
+//
+// If err != nil returns nil and error.
+func (g *GenericUtility[T, K]) StructToMap(model *T, tag string, opts ...MapOptions) (map[string]any, error) {
+	return StructToMap(model, tag, opts...)
+}
+
+// MapToStruct converts the given map[string]any to the struct *T, matching keys
+// to names from the "koanf" or "json" tags.
+//
+// If err != nil returns nil and error.
+func (g *GenericUtility[T, K]) MapToStruct(m map[string]any, model *T, opts ...MapOptions) (*T, error) {
+	return MapToStruct(m, model, opts...)
+}
+
+// FakeFill fills all exported fields of the given struct *T (recursively) with
+// random, but type-correct values (by the "fake" tags) for the test fixtures.
+//
+// If err != nil returns nil and error.
+func (g *GenericUtility[T, K]) FakeFill(model *T, opts ...FakeOptions) (*T, error) {
+	return FakeFill(model, opts...)
+}
+
+// RandomChoice returns a random element of the given slice using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns zero-value for the type T and error.
+func (g *GenericUtility[T, K]) RandomChoice(s []T) (T, error) {
+	return RandomChoice(s)
+}
+
+// RandomSample returns n random elements of the given slice (without
+// repetitions of the same elements) in a random order using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns nil and error.
+func (g *GenericUtility[T, K]) RandomSample(s []T, n int) ([]T, error) {
+	return RandomSample(s, n)
+}
+
+// Shuffle shuffles elements of the given slice in place using built-in
+// "crypto/rand" package.
+//
+// If err != nil returns error.
+func (g *GenericUtility[T, K]) Shuffle(s []T) error {
+	return Shuffle(s)
+}
+
+// WeightedChoice returns a random element of the given slice with the
+// probability, proportional to its weight, using built-in "crypto/rand"
+// package.
+//
+// If err != nil returns zero-value for the type T and error.
+func (g *GenericUtility[T, K]) WeightedChoice(s []T, weights []float64) (T, error) {
+	return WeightedChoice(s, weights)
+}
+
+// RandomChoiceWith returns a random element of the given slice with the source
+// of randomness of the given Generator.
+//
+// If err != nil returns zero-value for the type T and error.
+func (g *GenericUtility[T, K]) RandomChoiceWith(gen *Generator, s []T) (T, error) {
+	return RandomChoiceWith(gen, s)
+}
+
+// RandomSampleWith returns n random elements of the given slice with the source
+// of randomness of the given Generator.
+//
+// If err != nil returns nil and error.
+func (g *GenericUtility[T, K]) RandomSampleWith(gen *Generator, s []T, n int) ([]T, error) {
+	return RandomSampleWith(gen, s, n)
+}
+
+// ShuffleWith shuffles elements of the given slice in place with the source of
+// randomness of the given Generator.
+//
+// If err != nil returns error.
+func (g *GenericUtility[T, K]) ShuffleWith(gen *Generator, s []T) error {
+	return ShuffleWith(gen, s)
+}
+
+// WeightedChoiceWith returns a random element of the given slice with the
+// probability, proportional to its weight, with the source of randomness of
+// the given Generator.
+//
+// If err != nil returns zero-value for the type T and error.
+func (g *GenericUtility[T, K]) WeightedChoiceWith(gen *Generator, s []T, weights []float64) (T, error) {
+	return WeightedChoiceWith(gen, s, weights)
+}
+
 // ParseFileToStruct parses the given file from path to struct *T using
 // "knadh/koanf" package.
 //
@@ -143,3 +706,45 @@ func (g *GenericUtility[T, K]) Marshal(model *T) ([]byte, error) {
 func (g *GenericUtility[T, K]) Unmarshal(data []byte, model *T) (*T, error) {
 	return Unmarshal(data, model)
 }
+
+// MarshalMsgPack converts struct *T to MessagePack data (byte slice) with the
+// same rules (and the "json" struct tags) as the Marshal function.
+//
+// If err != nil returns zero-value for a byte slice and error.
+func (g *GenericUtility[T, K]) MarshalMsgPack(model *T) ([]byte, error) {
+	return MarshalMsgPack(model)
+}
+
+// UnmarshalMsgPack converts MessagePack data (byte slice) to struct *T with the
+// same rules (and the "json" struct tags) as the Unmarshal function.
+//
+// If err != nil returns zero-value for a struct and error.
+func (g *GenericUtility[T, K]) UnmarshalMsgPack(data []byte, model *T) (*T, error) {
+	return UnmarshalMsgPack(data, model)
+}
+
+// MarshalCBOR converts struct *T to CBOR (RFC 8949) data (byte slice) with the
+// same rules (and the "json" struct tags) as the Marshal function.
+//
+// If err != nil returns zero-value for a byte slice and error.
+func (g *GenericUtility[T, K]) MarshalCBOR(model *T) ([]byte, error) {
+	return MarshalCBOR(model)
+}
+
+// UnmarshalCBOR converts CBOR (RFC 8949) data (byte slice) to struct *T with
+// the same rules (and the "json" struct tags) as the Unmarshal function.
+//
+// If err != nil returns zero-value for a struct and error.
+func (g *GenericUtility[T, K]) UnmarshalCBOR(data []byte, model *T) (*T, error) {
+	return UnmarshalCBOR(data, model)
+}
+
+// UnmarshalNoCopy converts JSON data (byte slice) to struct *T, like the
+// Unmarshal function does, but string values alias the given data without
+// copying. Decoded strings are valid only while the data is alive and not
+// modified.
+//
+// If err != nil returns zero-value for a struct and error.
+func (g *GenericUtility[T, K]) UnmarshalNoCopy(data []byte, model *T) (*T, error) {
+	return UnmarshalNoCopy(data, model)
+}
@@ -1,6 +1,22 @@
 package gosl
 
-import jsoniter "github.com/json-iterator/go"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
 
 // Marshal converts struct *T to JSON data (byte slice) using jsoniter.Marshal
 // with a default configuration. A 100% compatible drop-in replacement of
@@ -78,3 +94,712 @@ func Unmarshal[T any](data []byte, model *T) (*T, error) {
 
 	return model, nil
 }
+
+// JSONKind represents a kind of the dynamic JSON value.
+type JSONKind uint8
+
+// Kinds of the dynamic JSON value.
+const (
+	JSONNull JSONKind = iota
+	JSONBool
+	JSONNumber
+	JSONString
+	JSONArray
+	JSONObject
+)
+
+// String returns a name of the kind.
+func (k JSONKind) String() string {
+	switch k {
+	case JSONBool:
+		return "bool"
+	case JSONNumber:
+		return "number"
+	case JSONString:
+		return "string"
+	case JSONArray:
+		return "array"
+	case JSONObject:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// JSONValue represents a dynamic JSON value (object, array, string, number,
+// bool or null) for schemaless documents. Numbers are stored as exact literals
+// (like json.Number), objects keep the order of their keys.
+//
+// The zero-value is a JSON null, a nil *JSONValue is treated as a missing
+// value. JSONValue implements json.Marshaler and json.Unmarshaler interfaces,
+// so it can be embedded in typed structs as a raw field.
+type JSONValue struct {
+	kind   JSONKind
+	b      bool
+	s      string // string value or number literal
+	items  []*JSONValue
+	keys   []string
+	fields map[string]*JSONValue
+}
+
+// ParseJSONValue parses JSON data (byte slice) to the dynamic *JSONValue.
+//
+// If err != nil returns zero-value for a *JSONValue and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		v, err := gosl.ParseJSONValue([]byte(`{"user":{"id":9007199254740993,"tags":["admin"]}}`))
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		id, err := v.Get("user.id").Int()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id, v.Get("user.tags.0"))
+//	}
+func ParseJSONValue(data []byte) (*JSONValue, error) {
+	v := &JSONValue{}
+	if err := v.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// NewJSONValue converts the given Go value to the dynamic *JSONValue, using
+// the same rules as the Marshal function.
+//
+// If err != nil returns zero-value for a *JSONValue and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		v, err := gosl.NewJSONValue(map[string]any{"id": 1, "name": "Viktor"})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(v)
+//	}
+func NewJSONValue(value any) (*JSONValue, error) {
+	switch v := value.(type) {
+	case *JSONValue:
+		if v == nil {
+			return &JSONValue{}, nil
+		}
+		return v, nil
+	case JSONValue:
+		return &v, nil
+	case nil:
+		return &JSONValue{}, nil
+	case bool:
+		return &JSONValue{kind: JSONBool, b: v}, nil
+	case string:
+		return &JSONValue{kind: JSONString, s: v}, nil
+	case json.Number:
+		if _, err := strconv.ParseFloat(string(v), 64); err != nil {
+			return nil, fmt.Errorf("error: invalid JSON number %q", string(v))
+		}
+		return &JSONValue{kind: JSONNumber, s: string(v)}, nil
+	case int:
+		return &JSONValue{kind: JSONNumber, s: strconv.Itoa(v)}, nil
+	case int64:
+		return &JSONValue{kind: JSONNumber, s: strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return &JSONValue{kind: JSONNumber, s: strconv.FormatUint(v, 10)}, nil
+	}
+
+	data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJSONValue(data)
+}
+
+// Kind returns the kind of the value. For a nil value returns JSONNull.
+func (v *JSONValue) Kind() JSONKind {
+	if v == nil {
+		return JSONNull
+	}
+
+	return v.kind
+}
+
+// IsNull reports whether the value is a JSON null (or missing).
+func (v *JSONValue) IsNull() bool {
+	return v.Kind() == JSONNull
+}
+
+// errJSONKind creates an error for the typed accessors of the JSONValue.
+func (v *JSONValue) errJSONKind(expected JSONKind) error {
+	if v == nil {
+		return fmt.Errorf("error: JSON value is missing, expected %s", expected)
+	}
+
+	return fmt.Errorf("error: JSON value is %s, expected %s", v.kind, expected)
+}
+
+// Bool returns the value as a bool.
+//
+// If the value is not a bool, returns false and error.
+func (v *JSONValue) Bool() (bool, error) {
+	if v.Kind() != JSONBool {
+		return false, v.errJSONKind(JSONBool)
+	}
+
+	return v.b, nil
+}
+
+// Int returns the value as an int64 without loss of precision (for ex., 1e3
+// or 1.0 are allowed, but 1.5 is not).
+//
+// If the value is not a number or is not an exact int64, returns 0 and error.
+func (v *JSONValue) Int() (int64, error) {
+	if v.Kind() != JSONNumber {
+		return 0, v.errJSONKind(JSONNumber)
+	}
+
+	if i, err := strconv.ParseInt(v.s, 10, 64); err == nil {
+		return i, nil
+	}
+
+	r, ok := new(big.Rat).SetString(v.s)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("error: JSON number %s is not an exact int64", v.s)
+	}
+
+	return r.Num().Int64(), nil
+}
+
+// Float returns the value as a float64.
+//
+// If the value is not a number, returns 0 and error.
+func (v *JSONValue) Float() (float64, error) {
+	if v.Kind() != JSONNumber {
+		return 0, v.errJSONKind(JSONNumber)
+	}
+
+	return strconv.ParseFloat(v.s, 64)
+}
+
+// Number returns the value as an exact json.Number.
+//
+// If the value is not a number, returns zero-value for a json.Number and
+// error.
+func (v *JSONValue) Number() (json.Number, error) {
+	if v.Kind() != JSONNumber {
+		return "", v.errJSONKind(JSONNumber)
+	}
+
+	return json.Number(v.s), nil
+}
+
+// String returns the content for a JSON string and the compact JSON encoding
+// for other kinds. For a nil value returns zero-value for a string.
+func (v *JSONValue) String() string {
+	switch v.Kind() {
+	case JSONString:
+		return v.s
+	case JSONNull:
+		if v == nil {
+			return ""
+		}
+	}
+
+	data, _ := v.MarshalJSON()
+
+	return string(data)
+}
+
+// Len returns the number of elements of the array or the number of keys of
+// the object. For other kinds returns 0.
+func (v *JSONValue) Len() int {
+	switch v.Kind() {
+	case JSONArray:
+		return len(v.items)
+	case JSONObject:
+		return len(v.keys)
+	default:
+		return 0
+	}
+}
+
+// Keys returns keys of the object in the original order. For other kinds
+// returns nil.
+func (v *JSONValue) Keys() []string {
+	if v.Kind() != JSONObject {
+		return nil
+	}
+
+	return slices.Clone(v.keys)
+}
+
+// Index returns the element of the array by the given index.
+//
+// If the value is not an array or index is out of range, returns nil.
+func (v *JSONValue) Index(i int) *JSONValue {
+	if v.Kind() != JSONArray || i < 0 || i >= len(v.items) {
+		return nil
+	}
+
+	return v.items[i]
+}
+
+// Field returns the value of the object by the given key.
+//
+// If the value is not an object or has no such key, returns nil.
+func (v *JSONValue) Field(key string) *JSONValue {
+	if v.Kind() != JSONObject {
+		return nil
+	}
+
+	return v.fields[key]
+}
+
+// Get returns the nested value by the given path. The path is a list of keys
+// and array indexes, separated by "." delimiter (for ex., "user.tags.0"), or a
+// JSON Pointer (for ex., "/user/tags/0").
+//
+// If the nested value is not found, returns nil.
+func (v *JSONValue) Get(path string) *JSONValue {
+	if path == "" {
+		return v
+	}
+
+	var segments []string
+	if strings.HasPrefix(path, "/") {
+		segments = strings.Split(path[1:], "/")
+		for i := range segments {
+			segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segments[i])
+		}
+	} else {
+		segments = strings.Split(path, ".")
+	}
+
+	current := v
+	for _, segment := range segments {
+		switch current.Kind() {
+		case JSONObject:
+			current = current.fields[segment]
+		case JSONArray:
+			i, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil
+			}
+			current = current.Index(i)
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
+
+// Elements returns an iterator over index-value pairs of the array. For other
+// kinds the iterator is empty.
+func (v *JSONValue) Elements() iter.Seq2[int, *JSONValue] {
+	return func(yield func(int, *JSONValue) bool) {
+		if v.Kind() != JSONArray {
+			return
+		}
+
+		for i, item := range v.items {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// Members returns an iterator over key-value pairs of the object in the
+// original order of the keys. For other kinds the iterator is empty.
+func (v *JSONValue) Members() iter.Seq2[string, *JSONValue] {
+	return func(yield func(string, *JSONValue) bool) {
+		if v.Kind() != JSONObject {
+			return
+		}
+
+		for _, key := range v.keys {
+			if !yield(key, v.fields[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Set sets the value of the object by the given key. The value is converted
+// with the NewJSONValue function. A JSON null becomes an empty object.
+//
+// If the value is not an object, returns error.
+func (v *JSONValue) Set(key string, value any) error {
+	if v == nil {
+		return v.errJSONKind(JSONObject)
+	}
+
+	if v.kind == JSONNull {
+		*v = JSONValue{kind: JSONObject}
+	}
+
+	if v.kind != JSONObject {
+		return v.errJSONKind(JSONObject)
+	}
+
+	jv, err := NewJSONValue(value)
+	if err != nil {
+		return err
+	}
+
+	if v.fields == nil {
+		v.fields = map[string]*JSONValue{}
+	}
+
+	if _, exists := v.fields[key]; !exists {
+		v.keys = append(v.keys, key)
+	}
+	v.fields[key] = jv
+
+	return nil
+}
+
+// Delete deletes the key from the object, reports whether the key was found.
+func (v *JSONValue) Delete(key string) bool {
+	if v.Kind() != JSONObject {
+		return false
+	}
+
+	if _, exists := v.fields[key]; !exists {
+		return false
+	}
+
+	delete(v.fields, key)
+	v.keys = slices.DeleteFunc(v.keys, func(k string) bool { return k == key })
+
+	return true
+}
+
+// SetIndex sets the element of the array by the given index. The value is
+// converted with the NewJSONValue function.
+//
+// If the value is not an array or index is out of range, returns error.
+func (v *JSONValue) SetIndex(i int, value any) error {
+	if v.Kind() != JSONArray {
+		return v.errJSONKind(JSONArray)
+	}
+
+	if i < 0 || i >= len(v.items) {
+		return fmt.Errorf("error: index %d is out of range of JSON array with length %d", i, len(v.items))
+	}
+
+	jv, err := NewJSONValue(value)
+	if err != nil {
+		return err
+	}
+
+	v.items[i] = jv
+
+	return nil
+}
+
+// Append appends the values to the array. Values are converted with the
+// NewJSONValue function. A JSON null becomes an empty array.
+//
+// If the value is not an array, returns error.
+func (v *JSONValue) Append(values ...any) error {
+	if v == nil {
+		return v.errJSONKind(JSONArray)
+	}
+
+	if v.kind == JSONNull {
+		*v = JSONValue{kind: JSONArray}
+	}
+
+	if v.kind != JSONArray {
+		return v.errJSONKind(JSONArray)
+	}
+
+	for _, value := range values {
+		jv, err := NewJSONValue(value)
+		if err != nil {
+			return err
+		}
+
+		v.items = append(v.items, jv)
+	}
+
+	return nil
+}
+
+// Interface converts the value to the generic Go value (nil, bool,
+// json.Number, string, []any or map[string]any).
+func (v *JSONValue) Interface() any {
+	switch v.Kind() {
+	case JSONBool:
+		return v.b
+	case JSONNumber:
+		return json.Number(v.s)
+	case JSONString:
+		return v.s
+	case JSONArray:
+		a := make([]any, len(v.items))
+		for i, item := range v.items {
+			a[i] = item.Interface()
+		}
+		return a
+	case JSONObject:
+		m := make(map[string]any, len(v.keys))
+		for _, key := range v.keys {
+			m[key] = v.fields[key].Interface()
+		}
+		return m
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v JSONValue) MarshalJSON() ([]byte, error) {
+	stream := jsoniter.ConfigCompatibleWithStandardLibrary.BorrowStream(nil)
+	defer jsoniter.ConfigCompatibleWithStandardLibrary.ReturnStream(stream)
+
+	v.writeTo(stream)
+	if stream.Error != nil {
+		return nil, stream.Error
+	}
+
+	return slices.Clone(stream.Buffer()), nil
+}
+
+// writeTo writes the value to the jsoniter stream.
+func (v *JSONValue) writeTo(stream *jsoniter.Stream) {
+	switch v.Kind() {
+	case JSONBool:
+		stream.WriteBool(v.b)
+	case JSONNumber:
+		stream.WriteRaw(v.s)
+	case JSONString:
+		stream.WriteString(v.s)
+	case JSONArray:
+		stream.WriteArrayStart()
+		for i, item := range v.items {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			item.writeTo(stream)
+		}
+		stream.WriteArrayEnd()
+	case JSONObject:
+		stream.WriteObjectStart()
+		for i, key := range v.keys {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(key)
+			v.fields[key].writeTo(stream)
+		}
+		stream.WriteObjectEnd()
+	default:
+		stream.WriteNil()
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *JSONValue) UnmarshalJSON(data []byte) error {
+	if !json.Valid(data) {
+		return errors.New("error: invalid JSON data for the dynamic JSON value")
+	}
+
+	it := jsoniter.ConfigCompatibleWithStandardLibrary.BorrowIterator(data)
+	defer jsoniter.ConfigCompatibleWithStandardLibrary.ReturnIterator(it)
+
+	*v = *readJSONValue(it)
+	if it.Error != nil && !errors.Is(it.Error, io.EOF) {
+		return it.Error
+	}
+
+	return nil
+}
+
+// readJSONValue reads the next value from the jsoniter iterator.
+func readJSONValue(it *jsoniter.Iterator) *JSONValue {
+	switch it.WhatIsNext() {
+	case jsoniter.BoolValue:
+		return &JSONValue{kind: JSONBool, b: it.ReadBool()}
+	case jsoniter.NumberValue:
+		return &JSONValue{kind: JSONNumber, s: string(it.ReadNumber())}
+	case jsoniter.StringValue:
+		return &JSONValue{kind: JSONString, s: it.ReadString()}
+	case jsoniter.ArrayValue:
+		v := &JSONValue{kind: JSONArray, items: []*JSONValue{}}
+		it.ReadArrayCB(func(it *jsoniter.Iterator) bool {
+			v.items = append(v.items, readJSONValue(it))
+			return it.Error == nil
+		})
+		return v
+	case jsoniter.ObjectValue:
+		v := &JSONValue{kind: JSONObject, fields: map[string]*JSONValue{}}
+		it.ReadObjectCB(func(it *jsoniter.Iterator, key string) bool {
+			if _, exists := v.fields[key]; !exists {
+				v.keys = append(v.keys, key)
+			}
+			v.fields[key] = readJSONValue(it)
+			return it.Error == nil
+		})
+		return v
+	default:
+		it.ReadNil()
+		return &JSONValue{}
+	}
+}
+
+// UnmarshalNoCopy converts JSON data (byte slice) to struct *T, like the
+// Unmarshal function does, but string values alias the given data without
+// copying (zero-copy decoding, like the ToString function does).
+//
+// Decoded strings are valid only while the data is alive and not modified, so
+// use this function only for read-only processing (for ex., of a request).
+// Strings with escape sequences must be unescaped, so if the data contains any
+// backslash, all strings are copied. Map keys and strings inside interface
+// values are always copied.
+//
+// If err != nil returns zero-value for a struct and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		json := []byte(`{"id":1,"name":"Viktor"}`)
+//		model := &user{}
+//
+//		u, err := gosl.UnmarshalNoCopy(json, model) // u.Name aliases json
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(u)
+//	}
+func UnmarshalNoCopy[T any](data []byte, model *T) (*T, error) {
+	it := jsonNoCopyAPI.BorrowIterator(data)
+	defer jsonNoCopyAPI.ReturnIterator(it)
+
+	// Strings can alias the data only if there are no escape sequences.
+	if bytes.IndexByte(data, '\\') < 0 {
+		it.Attachment = jsonNoCopyAttachment{}
+	}
+
+	it.ReadVal(&model)
+	if it.Error != nil {
+		return nil, it.Error
+	}
+
+	// Check, if data has bytes after the value.
+	if next := it.WhatIsNext(); next != jsoniter.InvalidValue || !errors.Is(it.Error, io.EOF) {
+		return nil, errors.New("error: invalid JSON data, there are bytes left after unmarshal")
+	}
+
+	return model, nil
+}
+
+// jsonNoCopyAPI represents a jsoniter configuration, compatible with the
+// "encoding/json" standard lib, with the zero-copy decoder for strings.
+var jsonNoCopyAPI = func() jsoniter.API {
+	api := jsoniter.Config{
+		EscapeHTML:             true,
+		SortMapKeys:            true,
+		ValidateJsonRawMessage: true,
+	}.Froze()
+	api.RegisterExtension(&jsonNoCopyExtension{})
+
+	return api
+}()
+
+// jsonNoCopyAttachment marks the iterator, which data has no escape sequences.
+type jsonNoCopyAttachment struct{}
+
+// jsonNoCopyExtension represents a jsoniter extension with the zero-copy
+// decoder for strings.
+type jsonNoCopyExtension struct {
+	jsoniter.DummyExtension
+}
+
+// CreateDecoder creates the zero-copy decoder for string types without custom
+// unmarshalers.
+func (e *jsonNoCopyExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	t := typ.Type1()
+	if t.Kind() != reflect.String {
+		return nil
+	}
+
+	ptr := reflect.PointerTo(t)
+	if ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	return jsonNoCopyStringDecoder{}
+}
+
+// jsonNoCopyStringDecoder represents the zero-copy decoder for strings.
+type jsonNoCopyStringDecoder struct{}
+
+// Decode decodes the string value, aliasing the data of the iterator.
+func (jsonNoCopyStringDecoder) Decode(ptr unsafe.Pointer, it *jsoniter.Iterator) {
+	switch it.WhatIsNext() {
+	case jsoniter.NilValue:
+		it.ReadNil()
+		*(*string)(ptr) = "" // like the Unmarshal function does
+	case jsoniter.StringValue:
+		if _, ok := it.Attachment.(jsonNoCopyAttachment); !ok {
+			*(*string)(ptr) = it.ReadString()
+			return
+		}
+
+		b := it.ReadStringAsSlice()
+
+		// Check control characters, like the ReadString method does.
+		for _, c := range b {
+			if c < ' ' {
+				it.ReportError("ReadString", fmt.Sprintf("invalid control character found: %d", c))
+				return
+			}
+		}
+
+		*(*string)(ptr) = unsafe.String(unsafe.SliceData(b), len(b))
+	default:
+		*(*string)(ptr) = it.ReadString() // reports the error
+	}
+}
@@ -1,7 +1,14 @@
 package gosl
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,3 +146,432 @@ func TestUnmarshal(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, u, json)
 }
+
+func BenchmarkParseJSONValue(b *testing.B) {
+	d := []byte(`{"id":1,"name":"Viktor","email":"my@mail.com","tags":["admin","user"],"address":{"city":"Moscow"}}`)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseJSONValue(d)
+	}
+}
+
+func TestParseJSONValue(t *testing.T) {
+	data := []byte(`{"id":9007199254740993,"name":"Viktor","price":10.5,"exp":1e3,"active":true,"none":null,"tags":["admin","user"],"address":{"city":"Moscow","a/b":1}}`)
+
+	v, err := ParseJSONValue(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, JSONObject, v.Kind())
+	assert.EqualValues(t, []string{"id", "name", "price", "exp", "active", "none", "tags", "address"}, v.Keys())
+	assert.EqualValues(t, 8, v.Len())
+
+	id, err := v.Get("id").Int()
+	require.NoError(t, err)
+	assert.EqualValues(t, int64(9007199254740993), id)
+
+	exp, err := v.Get("exp").Int()
+	require.NoError(t, err)
+	assert.EqualValues(t, int64(1000), exp)
+
+	_, err = v.Get("price").Int()
+	require.Error(t, err)
+
+	price, err := v.Get("price").Float()
+	require.NoError(t, err)
+	assert.EqualValues(t, 10.5, price)
+
+	n, err := v.Field("price").Number()
+	require.NoError(t, err)
+	assert.EqualValues(t, json.Number("10.5"), n)
+
+	active, err := v.Get("active").Bool()
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	assert.EqualValues(t, "Viktor", v.Get("name").String())
+	assert.EqualValues(t, "user", v.Get("tags.1").String())
+	assert.EqualValues(t, "user", v.Get("/tags/1").String())
+	assert.EqualValues(t, "1", v.Get("/address/a~1b").String())
+	assert.EqualValues(t, `["admin","user"]`, v.Get("tags").String())
+	assert.EqualValues(t, "null", v.Get("none").String())
+	assert.True(t, v.Get("none").IsNull())
+	assert.EqualValues(t, JSONString, v.Get("address.city").Kind())
+	assert.EqualValues(t, v, v.Get(""))
+
+	// Missing values.
+	assert.Nil(t, v.Get("unknown.path"))
+	assert.Nil(t, v.Get("tags.two"))
+	assert.Nil(t, v.Get("tags.5"))
+	assert.Nil(t, v.Get("name.first"))
+	assert.Nil(t, v.Index(0))
+	assert.Nil(t, v.Get("tags").Field("x"))
+	assert.True(t, v.Get("unknown").IsNull())
+	assert.EqualValues(t, "", v.Get("unknown").String())
+	assert.Nil(t, v.Get("name").Keys())
+	assert.Zero(t, v.Get("name").Len())
+
+	_, err = v.Get("unknown").Int()
+	require.Error(t, err)
+
+	_, err = v.Get("name").Float()
+	require.Error(t, err)
+
+	_, err = v.Get("name").Bool()
+	require.Error(t, err)
+
+	_, err = v.Get("name").Number()
+	require.Error(t, err)
+
+	// Round trip keeps the order of keys and exact numbers.
+	out, err := v.MarshalJSON()
+	require.NoError(t, err)
+	assert.EqualValues(t, string(data), string(out))
+
+	_, err = ParseJSONValue([]byte(`{"id":`))
+	require.Error(t, err)
+
+	_, err = ParseJSONValue([]byte(`{} []`))
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	v, err = g.ParseJSONValue(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, "Viktor", v.Get("name").String())
+}
+
+func TestJSONValue_Iteration(t *testing.T) {
+	v, err := ParseJSONValue([]byte(`{"b":1,"a":2,"list":[3,4,5]}`))
+	require.NoError(t, err)
+
+	keys := []string{}
+	for key, value := range v.Members() {
+		keys = append(keys, key+"="+value.String())
+	}
+	assert.EqualValues(t, []string{"b=1", "a=2", `list=[3,4,5]`}, keys)
+
+	sum := int64(0)
+	for i, value := range v.Get("list").Elements() {
+		n, err := value.Int()
+		require.NoError(t, err)
+		sum += n * int64(i)
+		if i == 1 {
+			break
+		}
+	}
+	assert.EqualValues(t, 4, sum)
+
+	for range v.Elements() {
+		t.Fatal("object has no elements")
+	}
+
+	for range v.Get("list").Members() {
+		t.Fatal("array has no members")
+	}
+}
+
+func TestJSONValue_Mutation(t *testing.T) {
+	v := &JSONValue{}
+
+	require.NoError(t, v.Set("id", 1))
+	require.NoError(t, v.Set("name", "Viktor"))
+	require.NoError(t, v.Set("tags", []string{"admin"}))
+	require.NoError(t, v.Set("address", map[string]any{"city": "Moscow"}))
+	require.NoError(t, v.Set("id", json.Number("2")))
+	require.NoError(t, v.Get("tags").Append("user", nil))
+	require.NoError(t, v.Get("tags").SetIndex(0, true))
+	require.NoError(t, v.Set("nested", &JSONValue{}))
+	require.NoError(t, v.Get("nested").Append(int64(1), uint64(2)))
+	assert.True(t, v.Delete("address"))
+	assert.False(t, v.Delete("address"))
+
+	out, err := v.MarshalJSON()
+	require.NoError(t, err)
+	assert.EqualValues(t, `{"id":2,"name":"Viktor","tags":[true,"user",null],"nested":[1,2]}`, string(out))
+
+	assert.EqualValues(t, map[string]any{
+		"id":     json.Number("2"),
+		"name":   "Viktor",
+		"tags":   []any{true, "user", nil},
+		"nested": []any{json.Number("1"), json.Number("2")},
+	}, v.Interface())
+
+	require.Error(t, v.Append(1))
+	require.Error(t, v.SetIndex(0, 1))
+	require.Error(t, v.Get("tags").SetIndex(5, 1))
+	require.Error(t, v.Get("tags").SetIndex(0, func() {}))
+	require.Error(t, v.Get("name").Set("x", 1))
+	require.Error(t, v.Set("x", func() {}))
+	require.Error(t, v.Get("tags").Append(func() {}))
+	require.Error(t, v.Get("unknown").Set("x", 1))
+	require.Error(t, v.Get("unknown").Append(1))
+	assert.False(t, v.Get("tags").Delete("x"))
+
+	_, err = NewJSONValue(json.Number("abc"))
+	require.Error(t, err)
+
+	jv, err := NewJSONValue(nil)
+	require.NoError(t, err)
+	assert.True(t, jv.IsNull())
+
+	jv, err = NewJSONValue((*JSONValue)(nil))
+	require.NoError(t, err)
+	assert.True(t, jv.IsNull())
+
+	jv, err = NewJSONValue(JSONValue{kind: JSONBool, b: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, "true", jv.String())
+
+	g := Utility{} // tests for method
+
+	jv, err = g.NewJSONValue(false)
+	require.NoError(t, err)
+	assert.EqualValues(t, JSONBool, jv.Kind())
+}
+
+func TestJSONValue_MarshalUnmarshal(t *testing.T) {
+	type event struct {
+		ID      int        `json:"id"`
+		Payload JSONValue  `json:"payload"`
+		Meta    *JSONValue `json:"meta"`
+	}
+
+	data := []byte(`{"id":1,"payload":{"amount":12345678901234567890,"items":[1,2]},"meta":null}`)
+
+	e, err := Unmarshal(data, &event{})
+	require.NoError(t, err)
+	assert.EqualValues(t, JSONObject, e.Payload.Kind())
+	assert.EqualValues(t, "12345678901234567890", e.Payload.Get("amount").String())
+	assert.Nil(t, e.Meta)
+
+	out, err := Marshal(e)
+	require.NoError(t, err)
+	assert.EqualValues(t, string(data), string(out))
+
+	// Dynamic values in the binary formats.
+	bin, err := MarshalMsgPack(e)
+	require.NoError(t, err)
+
+	e2, err := UnmarshalMsgPack(bin, &event{})
+	require.NoError(t, err)
+
+	n, err := e2.Payload.Get("items.1").Int()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	assert.EqualValues(t, "null", JSONKind(42).String())
+	assert.EqualValues(t, "bool", JSONBool.String())
+}
+
+func BenchmarkUnmarshal_Strings_8(b *testing.B) {
+	type user struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Attr1 string `json:"attr_1"`
+		Attr2 string `json:"attr_2"`
+		Attr3 string `json:"attr_3"`
+		Attr4 string `json:"attr_4"`
+		Attr5 string `json:"attr_5"`
+		Attr6 string `json:"attr_6"`
+	}
+
+	d := []byte(`{"id":1,"name":"Viktor","email":"my@mail.com","attr_1":"one","attr_2":"two","attr_3":"three","attr_4":"four","attr_5":"five","attr_6":"six"}`)
+	u := &user{}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Unmarshal(d, u)
+	}
+}
+
+func BenchmarkUnmarshalNoCopy_Strings_8(b *testing.B) {
+	type user struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Attr1 string `json:"attr_1"`
+		Attr2 string `json:"attr_2"`
+		Attr3 string `json:"attr_3"`
+		Attr4 string `json:"attr_4"`
+		Attr5 string `json:"attr_5"`
+		Attr6 string `json:"attr_6"`
+	}
+
+	d := []byte(`{"id":1,"name":"Viktor","email":"my@mail.com","attr_1":"one","attr_2":"two","attr_3":"three","attr_4":"four","attr_5":"five","attr_6":"six"}`)
+	u := &user{}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = UnmarshalNoCopy(d, u)
+	}
+}
+
+// aliases reports whether the string s points into the byte slice b.
+func aliases(s string, b []byte) bool {
+	if s == "" || len(b) == 0 {
+		return false
+	}
+
+	p := uintptr(unsafe.Pointer(unsafe.StringData(s)))
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+
+	return p >= start && p < start+uintptr(len(b))
+}
+
+type noCopyStatus string
+
+func (s *noCopyStatus) UnmarshalText(text []byte) error {
+	*s = noCopyStatus(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestUnmarshalNoCopy(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+
+	type user struct {
+		ID       int               `json:"id"`
+		Name     string            `json:"name"`
+		Password string            `json:"-"`
+		Nickname *string           `json:"nickname"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Address  address           `json:"address"`
+		Status   noCopyStatus      `json:"status"`
+		Quoted   string            `json:"quoted,string"`
+		Keep     string            `json:"keep"`
+		Extra    any               `json:"extra"`
+	}
+
+	data := []byte(`{"id":1,"name":"Viktor","nickname":"vic","tags":["admin","user"],"labels":{"team":"core"},"address":{"city":"Moscow"},"status":"active","quoted":"\"q\"","keep":null,"extra":"copied"}`)
+
+	// Check, if decoded values are the same as with the Unmarshal function.
+	expected, err := Unmarshal(data, &user{Keep: "keep"})
+	require.NoError(t, err)
+
+	u, err := UnmarshalNoCopy(data, &user{Keep: "keep"})
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, u)
+
+	// Data with escape sequences: all strings are copied.
+	assert.False(t, aliases(u.Name, data))
+
+	data = []byte(`{"id":1,"name":"Viktor","nickname":"vic","tags":["admin","user"],"labels":{"team":"core"},"address":{"city":"Moscow"},"status":"active","keep":null,"extra":"copied"}`)
+
+	expected, err = Unmarshal(data, &user{Keep: "keep"})
+	require.NoError(t, err)
+
+	u, err = UnmarshalNoCopy(data, &user{Keep: "keep"})
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, u)
+
+	// Check, if string values alias the data.
+	assert.True(t, aliases(u.Name, data))
+	assert.True(t, aliases(*u.Nickname, data))
+	assert.True(t, aliases(u.Tags[1], data))
+	assert.True(t, aliases(u.Labels["team"], data))
+	assert.True(t, aliases(u.Address.City, data))
+	assert.False(t, aliases(string(u.Status), data)) // custom unmarshaler
+	assert.False(t, aliases(u.Extra.(string), data)) // interface value
+	assert.Empty(t, u.Keep)
+
+	// Check, if the Unmarshal function still copies strings.
+	expected, err = Unmarshal(data, &user{})
+	require.NoError(t, err)
+	assert.False(t, aliases(expected.Name, data))
+
+	// Modification of the data is visible in the aliased strings.
+	copy(data[bytes.Index(data, []byte("Viktor")):], "Victor")
+	assert.EqualValues(t, "Victor", u.Name)
+
+	_, err = UnmarshalNoCopy(nil, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalNoCopy([]byte(`{"id":1} x`), &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalNoCopy([]byte(`{"name":1}`), &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalNoCopy([]byte(`{"name":"Viktor`), &user{})
+	require.Error(t, err)
+
+	// Raw control characters in strings are rejected, like with the Unmarshal
+	// function.
+	for _, raw := range []string{"{\"name\":\"Vik\ttor\"}", "{\"tags\":[\"a\x00\"]}", "{\"nickname\":\"\n\"}"} {
+		_, expectedErr := Unmarshal([]byte(raw), &user{})
+		require.Error(t, expectedErr, "data %q", raw)
+
+		_, err = UnmarshalNoCopy([]byte(raw), &user{})
+		require.Error(t, err, "data %q", raw)
+	}
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	u, err = g.UnmarshalNoCopy(data, &user{})
+	require.NoError(t, err)
+	assert.True(t, aliases(u.Name, data))
+}
+
+func TestUnmarshalNoCopy_Concurrent(t *testing.T) {
+	type user struct {
+		ID   int      `json:"id"`
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	shared := []byte(`{"id":1,"name":"Viktor","tags":["admin","user"]}`)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+
+	for i := 0; i < 32; i++ {
+		wg.Add(2)
+
+		// Concurrent reads of the shared (read-only) data.
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				u, err := UnmarshalNoCopy(shared, &user{})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if u.Name != "Viktor" || u.Tags[1] != "user" {
+					errs <- errors.New("wrong decoded value")
+					return
+				}
+			}
+		}()
+
+		// Concurrent decoding of own buffers (with and without escapes).
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				name := fmt.Sprintf("user-%d-%d", i, j)
+				if j%2 == 0 {
+					name += `\n`
+				}
+
+				data := []byte(fmt.Sprintf(`{"id":%d,"name":"%s"}`, i, name))
+				u, err := UnmarshalNoCopy(data, &user{})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if u.ID != i || !strings.HasPrefix(u.Name, fmt.Sprintf("user-%d-%d", i, j)) {
+					errs <- errors.New("wrong decoded value")
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -1,7 +1,12 @@
 package gosl
 
 import (
+	"bytes"
 	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // ModifyByValue modify an unknown key in the given map[string]any by it value.
@@ -54,3 +59,469 @@ func ModifyByValue(m map[string]any, foundValue, newValue any) (foundKey bool, r
 
 	return foundKey, m
 }
+
+// caseMode represents a mode of the case conversion.
+type caseMode uint8
+
+// Modes of the case conversion.
+const (
+	caseLower caseMode = iota
+	caseUpper
+	casePascal
+	caseCamel
+)
+
+// ToSnakeCase converts the given string to the snake_case (for ex.,
+// "HTTPServerURL" to "http_server_url"). Words are split by non-alphanumeric
+// characters and by case changes with the acronyms handling.
+//
+// Returns the given string without allocations, if it's already in the
+// snake_case, otherwise makes a single allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ToSnakeCase("HTTPServerURL")
+//
+//		fmt.Println(s) // http_server_url
+//	}
+func ToSnakeCase(s string) string {
+	return convertCase(s, "_", caseLower)
+}
+
+// ToKebabCase converts the given string to the kebab-case (for ex.,
+// "HTTPServerURL" to "http-server-url"). Words are split by non-alphanumeric
+// characters and by case changes with the acronyms handling.
+//
+// Returns the given string without allocations, if it's already in the
+// kebab-case, otherwise makes a single allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ToKebabCase("HTTPServerURL")
+//
+//		fmt.Println(s) // http-server-url
+//	}
+func ToKebabCase(s string) string {
+	return convertCase(s, "-", caseLower)
+}
+
+// ToScreamingSnake converts the given string to the SCREAMING_SNAKE_CASE (for
+// ex., "HTTPServerURL" to "HTTP_SERVER_URL"), like names of the environment
+// variables. Words are split by non-alphanumeric characters and by case
+// changes with the acronyms handling.
+//
+// Returns the given string without allocations, if it's already in the
+// SCREAMING_SNAKE_CASE, otherwise makes a single allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ToScreamingSnake("serverURL")
+//
+//		fmt.Println(s) // SERVER_URL
+//	}
+func ToScreamingSnake(s string) string {
+	return convertCase(s, "_", caseUpper)
+}
+
+// ToCamelCase converts the given string to the camelCase (for ex.,
+// "http_server_url" to "httpServerUrl"). Words are split by non-alphanumeric
+// characters and by case changes with the acronyms handling.
+//
+// Returns the given string without allocations, if it's already in the
+// camelCase, otherwise makes a single allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ToCamelCase("HTTPServerURL")
+//
+//		fmt.Println(s) // httpServerUrl
+//	}
+func ToCamelCase(s string) string {
+	return convertCase(s, "", caseCamel)
+}
+
+// ToPascalCase converts the given string to the PascalCase (for ex.,
+// "http_server_url" to "HttpServerUrl"). Words are split by non-alphanumeric
+// characters and by case changes with the acronyms handling.
+//
+// Returns the given string without allocations, if it's already in the
+// PascalCase, otherwise makes a single allocation.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.ToPascalCase("http_server_url")
+//
+//		fmt.Println(s) // HttpServerUrl
+//	}
+func ToPascalCase(s string) string {
+	return convertCase(s, "", casePascal)
+}
+
+// convertCase converts the given string to the case with the separator between
+// words. Makes two passes: the first one calculates the length of the result
+// (and checks, if the result is equal to the given string), the second one
+// writes the result with a single allocation.
+func convertCase(s, sep string, mode caseMode) string {
+	n, pos, same := 0, 0, true
+
+	for w, i := 0, 0; ; w++ {
+		start, end := nextWord(s, i)
+		if start < 0 {
+			break
+		}
+
+		if w > 0 {
+			n += len(sep)
+			same = same && s[pos:start] == sep
+		} else {
+			same = start == 0
+		}
+
+		for k, j := 0, start; j < end; k++ {
+			r, size := utf8.DecodeRuneInString(s[j:end])
+			m := mapCaseRune(r, w, k, mode)
+			n += utf8.RuneLen(m)
+			same = same && m == r
+			j += size
+		}
+
+		pos, i = end, end
+	}
+
+	// Check, if the string is already in the given case.
+	if same && pos == len(s) {
+		return s
+	}
+
+	if n == 0 {
+		return ""
+	}
+
+	b := make([]byte, 0, n)
+
+	for w, i := 0, 0; ; w++ {
+		start, end := nextWord(s, i)
+		if start < 0 {
+			break
+		}
+
+		if w > 0 {
+			b = append(b, sep...)
+		}
+
+		for k, j := 0, start; j < end; k++ {
+			r, size := utf8.DecodeRuneInString(s[j:end])
+			b = utf8.AppendRune(b, mapCaseRune(r, w, k, mode))
+			j += size
+		}
+
+		i = end
+	}
+
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// mapCaseRune maps the rune with the index k of the word with the index w to
+// the given case mode.
+func mapCaseRune(r rune, w, k int, mode caseMode) rune {
+	switch {
+	case mode == caseUpper:
+		return unicode.ToUpper(r)
+	case mode == caseLower, mode == caseCamel && w == 0, k > 0:
+		return unicode.ToLower(r)
+	default:
+		return unicode.ToTitle(r)
+	}
+}
+
+// nextWord returns the byte bounds of the next word in the given string,
+// starting from the byte index i. Words are split by non-alphanumeric
+// characters and by case changes: "HTTPServer_url2" has "HTTP", "Server" and
+// "url2" words. If there are no more words, returns -1 for the bounds.
+func nextWord(s string, i int) (start, end int) {
+	// Skip separators before the word.
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+
+	if i >= len(s) {
+		return -1, -1
+	}
+
+	start = i
+	prev, size := utf8.DecodeRuneInString(s[i:])
+	i += size
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+
+		if unicode.IsUpper(r) {
+			// Split "serverURL" or "v2Server" before the upper case letter.
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				break
+			}
+
+			// Split "HTTPServer" before the last upper case letter of the
+			// acronym, but keep plural acronyms like "IDs" or "URLs".
+			next, nextSize := utf8.DecodeRuneInString(s[i+size:])
+			if after, _ := utf8.DecodeRuneInString(s[i+size+nextSize:]); unicode.IsUpper(prev) && unicode.IsLower(next) &&
+				(next != 's' || unicode.IsLower(after)) {
+				break
+			}
+		}
+
+		prev = r
+		i += size
+	}
+
+	return start, i
+}
+
+// SlugOptions represents options for the Slugify function.
+type SlugOptions struct {
+	// Separator sets a separator between words of the slug ("-" by default).
+	Separator string
+
+	// MaxLength sets a maximum length of the slug in bytes. The slug is cut at
+	// the word boundary, if possible (no limit by default).
+	MaxLength int
+}
+
+// slugTransliterations represents a table of the ASCII transliterations for
+// the lowercase Latin-extended and Cyrillic letters.
+var slugTransliterations = map[rune]string{
+	// Latin-1 Supplement and Latin Extended-A.
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g", 'ĥ': "h", 'ħ': "h",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'ĳ': "ij", 'ĵ': "j", 'ķ': "k", 'ĸ': "k", 'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n", 'ŉ': "n", 'ŋ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'œ': "oe", 'ŕ': "r", 'ŗ': "r", 'ř': "r", 'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'ß': "ss", 'ſ': "s", 'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ŵ': "w", 'ý': "y", 'ÿ': "y", 'ŷ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+
+	// Cyrillic (Russian, Ukrainian, Belarusian, Serbian and Macedonian).
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// Slugify converts the given string to the URL slug: letters are transliterated
+// to the ASCII lowercase letters (common Latin-extended and Cyrillic ones),
+// other characters (including letters without transliteration) are replaced
+// with the separator ("-" by default), repeated separators are collapsed.
+//
+// With the MaxLength option, the slug is cut at the word boundary.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.Slugify("Привет, Мир! Crème Brûlée", gosl.SlugOptions{MaxLength: 20})
+//
+//		fmt.Println(s) // privet-mir-creme
+//	}
+func Slugify(s string, opts ...SlugOptions) string {
+	sep, maxLength := "-", 0
+	if len(opts) > 0 {
+		if opts[0].Separator != "" {
+			sep = opts[0].Separator
+		}
+		maxLength = opts[0].MaxLength
+	}
+
+	b := make([]byte, 0, len(s))
+	pending := false // separator before the next word
+
+	for _, r := range s {
+		r = unicode.ToLower(r)
+
+		var word string
+		switch {
+		case r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9'):
+			if pending && len(b) > 0 {
+				b = append(b, sep...)
+			}
+			b = append(b, byte(r))
+			pending = false
+			continue
+		case r == '\'' || r == '’':
+			continue // skip apostrophes inside words (for ex., "don't")
+		default:
+			var ok bool
+			if word, ok = slugTransliterations[r]; !ok {
+				// Skip combining marks (for ex., accents of the decomposed
+				// letters), other characters are separators.
+				if !unicode.Is(unicode.Mn, r) {
+					pending = true
+				}
+				continue
+			}
+		}
+
+		if word == "" {
+			continue
+		}
+
+		if pending && len(b) > 0 {
+			b = append(b, sep...)
+		}
+		b = append(b, word...)
+		pending = false
+	}
+
+	// Cut the slug at the word boundary.
+	if maxLength > 0 && len(b) > maxLength {
+		if i := bytes.LastIndex(b[:min(len(b), maxLength+len(sep))], []byte(sep)); i > 0 {
+			b = b[:i]
+		} else {
+			b = b[:maxLength]
+		}
+	}
+
+	return string(b)
+}
+
+// windowsReservedNames represents a list of the reserved file names on Windows.
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// SanitizeFilename removes characters, which are invalid in file names on the
+// common filesystems (Windows, macOS and Linux): path separators, control
+// characters and `<>:"|?*`. Trailing spaces and dots are removed too, reserved
+// names on Windows (like "CON" or "nul.txt") are prefixed with "_". The name
+// is cut to 255 bytes with the extension kept.
+//
+// If the sanitized name is empty (or "." and ".."), returns zero-value for a
+// string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s := gosl.SanitizeFilename("report: 2023/05?.pdf")
+//
+//		fmt.Println(s) // report 202305.pdf
+//	}
+func SanitizeFilename(name string) string {
+	b := make([]byte, 0, len(name))
+
+	for _, r := range name {
+		switch {
+		case r < 0x20 || r == 0x7f || r == utf8.RuneError:
+			continue
+		case strings.ContainsRune(`<>:"/\|?*`, r):
+			continue
+		default:
+			b = utf8.AppendRune(b, r)
+		}
+	}
+
+	s := strings.TrimSpace(strings.TrimRight(string(b), " ."))
+	if s == "" || s == "." || s == ".." {
+		return ""
+	}
+
+	// Check, if the name (without extension) is reserved on Windows.
+	base, _, _ := strings.Cut(s, ".")
+	for _, reserved := range windowsReservedNames {
+		if strings.EqualFold(strings.TrimRight(base, " "), reserved) {
+			s = "_" + s
+			break
+		}
+	}
+
+	// Cut the name to 255 bytes, keeping the extension and valid UTF-8.
+	const maxFilenameLength = 255
+	if len(s) > maxFilenameLength {
+		ext := ""
+		if i := strings.LastIndexByte(s, '.'); i > 0 && len(s)-i <= 16 {
+			ext = s[i:]
+		}
+
+		base := s[:maxFilenameLength-len(ext)]
+		for len(base) > 0 && !utf8.ValidString(base) {
+			base = base[:len(base)-1]
+		}
+
+		s = strings.TrimRight(base, " .") + ext
+	}
+
+	return s
+}
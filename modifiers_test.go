@@ -1,7 +1,9 @@
 package gosl
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,3 +43,180 @@ func TestModifyByValue(t *testing.T) {
 	assert.EqualValues(t, isFound2, true)
 	assert.EqualValues(t, result2, modified2)
 }
+
+var resultCase string
+
+func BenchmarkToSnakeCase(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = ToSnakeCase("HTTPServerURL")
+	}
+	resultCase = r
+}
+
+func BenchmarkToCamelCase(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = ToCamelCase("http_server_url")
+	}
+	resultCase = r
+}
+
+func TestCaseConversions(t *testing.T) {
+	for _, tc := range []struct {
+		s, snake, kebab, screaming, camel, pascal string
+	}{
+		{"", "", "", "", "", ""},
+		{"_-_", "", "", "", "", ""},
+		{"a", "a", "a", "A", "a", "A"},
+		{"HTTPServerURL", "http_server_url", "http-server-url", "HTTP_SERVER_URL", "httpServerUrl", "HttpServerUrl"},
+		{"http_server_url", "http_server_url", "http-server-url", "HTTP_SERVER_URL", "httpServerUrl", "HttpServerUrl"},
+		{"httpServerUrl", "http_server_url", "http-server-url", "HTTP_SERVER_URL", "httpServerUrl", "HttpServerUrl"},
+		{"HTTP_SERVER_URL", "http_server_url", "http-server-url", "HTTP_SERVER_URL", "httpServerUrl", "HttpServerUrl"},
+		{"http-server-url", "http_server_url", "http-server-url", "HTTP_SERVER_URL", "httpServerUrl", "HttpServerUrl"},
+		{"userID", "user_id", "user-id", "USER_ID", "userId", "UserId"},
+		{"UserIDs", "user_ids", "user-ids", "USER_IDS", "userIds", "UserIds"},
+		{"ID", "id", "id", "ID", "id", "Id"},
+		{"JSONData", "json_data", "json-data", "JSON_DATA", "jsonData", "JsonData"},
+		{"parseJSON", "parse_json", "parse-json", "PARSE_JSON", "parseJson", "ParseJson"},
+		{"  Hello,  World! ", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"already_snake", "already_snake", "already-snake", "ALREADY_SNAKE", "alreadySnake", "AlreadySnake"},
+		{"double__underscore", "double_underscore", "double-underscore", "DOUBLE_UNDERSCORE", "doubleUnderscore", "DoubleUnderscore"},
+		{"version2", "version2", "version2", "VERSION2", "version2", "Version2"},
+		{"HTTP2Server", "http2_server", "http2-server", "HTTP2_SERVER", "http2Server", "Http2Server"},
+		{"v2Server", "v2_server", "v2-server", "V2_SERVER", "v2Server", "V2Server"},
+		{"utf8Decoder", "utf8_decoder", "utf8-decoder", "UTF8_DECODER", "utf8Decoder", "Utf8Decoder"},
+		{"2fa_code", "2fa_code", "2fa-code", "2FA_CODE", "2faCode", "2faCode"},
+		{"i18n", "i18n", "i18n", "I18N", "i18n", "I18n"},
+		{"my.config.key", "my_config_key", "my-config-key", "MY_CONFIG_KEY", "myConfigKey", "MyConfigKey"},
+		{"ПриветМир", "привет_мир", "привет-мир", "ПРИВЕТ_МИР", "приветМир", "ПриветМир"},
+		{"straßeName", "straße_name", "straße-name", "STRAßE_NAME", "straßeName", "StraßeName"},
+		{"ÉcoleNormale", "école_normale", "école-normale", "ÉCOLE_NORMALE", "écoleNormale", "ÉcoleNormale"},
+		{"日本語テキスト", "日本語テキスト", "日本語テキスト", "日本語テキスト", "日本語テキスト", "日本語テキスト"},
+		{"invalid\xffutf8", "invalid_utf8", "invalid-utf8", "INVALID_UTF8", "invalidUtf8", "InvalidUtf8"},
+	} {
+		assert.Equal(t, tc.snake, ToSnakeCase(tc.s), "snake case of %q", tc.s)
+		assert.Equal(t, tc.kebab, ToKebabCase(tc.s), "kebab case of %q", tc.s)
+		assert.Equal(t, tc.screaming, ToScreamingSnake(tc.s), "screaming snake case of %q", tc.s)
+		assert.Equal(t, tc.camel, ToCamelCase(tc.s), "camel case of %q", tc.s)
+		assert.Equal(t, tc.pascal, ToPascalCase(tc.s), "pascal case of %q", tc.s)
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "http_server_url", g.ToSnakeCase("HTTPServerURL"))
+	assert.Equal(t, "http-server-url", g.ToKebabCase("HTTPServerURL"))
+	assert.Equal(t, "HTTP_SERVER_URL", g.ToScreamingSnake("HTTPServerURL"))
+	assert.Equal(t, "httpServerUrl", g.ToCamelCase("HTTPServerURL"))
+	assert.Equal(t, "HttpServerUrl", g.ToPascalCase("HTTPServerURL"))
+}
+
+func TestCaseConversions_Allocs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		convert func(string) string
+		same    string
+		other   string
+	}{
+		{"snake", ToSnakeCase, "http_server_url", "HTTPServerURL"},
+		{"kebab", ToKebabCase, "http-server-url", "HTTPServerURL"},
+		{"screaming", ToScreamingSnake, "HTTP_SERVER_URL", "HTTPServerURL"},
+		{"camel", ToCamelCase, "httpServerUrl", "HTTPServerURL"},
+		{"pascal", ToPascalCase, "HttpServerUrl", "HTTPServerURL"},
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			resultCase = tc.convert(tc.same)
+		})
+		assert.EqualValues(t, 0, allocs, "%s case of %q", tc.name, tc.same)
+
+		allocs = testing.AllocsPerRun(100, func() {
+			resultCase = tc.convert(tc.other)
+		})
+		assert.EqualValues(t, 1, allocs, "%s case of %q", tc.name, tc.other)
+	}
+}
+
+func BenchmarkSlugify(b *testing.B) {
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r = Slugify("Привет, Мир! Crème Brûlée & Co.")
+	}
+	resultCase = r
+}
+
+func TestSlugify(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		opts     []SlugOptions
+		expected string
+	}{
+		{"", nil, ""},
+		{"!!!", nil, ""},
+		{"Hello, World!", nil, "hello-world"},
+		{"  --Hello__World--  ", nil, "hello-world"},
+		{"Don't stop", nil, "dont-stop"},
+		{"It’s 2023", nil, "its-2023"},
+		{"Crème Brûlée", nil, "creme-brulee"},
+		{"Straße Ærø Œuvre Łódź", nil, "strasse-aero-oeuvre-lodz"},
+		{"Çağrı Şahin İstanbul", nil, "cagri-sahin-istanbul"},
+		{"Café", nil, "cafe"},
+		{"Привет, Мир!", nil, "privet-mir"},
+		{"Съешь же ещё этих мягких французских булок", nil, "sesh-zhe-eshchyo-etikh-myagkikh-frantsuzskikh-bulok"},
+		{"Їжак і ґанок, Європа", nil, "yizhak-i-ganok-yevropa"},
+		{"Đoković Љубљана", nil, "dokovic-ljubljana"},
+		{"abc日本def", nil, "abc-def"},
+		{"Hello, World!", []SlugOptions{{Separator: "_"}}, "hello_world"},
+		{"Hello, World!", []SlugOptions{{Separator: "--"}}, "hello--world"},
+		{"Hello, big World!", []SlugOptions{{MaxLength: 11}}, "hello-big"},
+		{"Hello, big World!", []SlugOptions{{MaxLength: 9}}, "hello-big"},
+		{"Hello, big World!", []SlugOptions{{MaxLength: 8}}, "hello"},
+		{"Hello, big World!", []SlugOptions{{MaxLength: 15}}, "hello-big-world"},
+		{"Hello, big World!", []SlugOptions{{MaxLength: 100}}, "hello-big-world"},
+		{"Supercalifragilistic word", []SlugOptions{{MaxLength: 5}}, "super"},
+		{"Hello, big World!", []SlugOptions{{Separator: "__", MaxLength: 11}}, "hello__big"},
+	} {
+		assert.Equal(t, tc.expected, Slugify(tc.s, tc.opts...), "slug of %q", tc.s)
+	}
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "privet-mir", g.Slugify("Привет, Мир!"))
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	for name, expected := range map[string]string{
+		"":                        "",
+		"report.pdf":              "report.pdf",
+		"report: 2023/05?.pdf":    "report 202305.pdf",
+		`a<b>c:d"e/f\g|h?i*j.txt`: "abcdefghij.txt",
+		"tab\tand\nnewline\x7f":   "tabandnewline",
+		"trailing dots...":        "trailing dots",
+		"  spaces  . ":            "spaces",
+		"..":                      "",
+		"???":                     "",
+		"CON":                     "_CON",
+		"nul.txt":                 "_nul.txt",
+		"Com1.tar.gz":             "_Com1.tar.gz",
+		"console.txt":             "console.txt",
+		"Привет мир.txt":          "Привет мир.txt",
+		"invalid\xff.txt":         "invalid.txt",
+	} {
+		assert.Equal(t, expected, SanitizeFilename(name), "file name %q", name)
+	}
+
+	long := strings.Repeat("я", 200) + ".txt"
+	s := SanitizeFilename(long)
+	assert.LessOrEqual(t, len(s), 255)
+	assert.True(t, strings.HasSuffix(s, ".txt"))
+	assert.True(t, utf8.ValidString(s))
+
+	s = SanitizeFilename(strings.Repeat("a", 300))
+	assert.Len(t, s, 255)
+
+	g := Utility{} // tests for method
+
+	assert.Equal(t, "report.pdf", g.SanitizeFilename("re/port.pdf"))
+}
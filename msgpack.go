@@ -0,0 +1,480 @@
+package gosl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// MarshalMsgPack converts struct *T to MessagePack data (byte slice) with the
+// same rules (and the "json" struct tags) as the Marshal function.
+//
+// If err != nil returns zero-value for a byte slice and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		u := &user{ID: 1, Name: "Viktor"}
+//
+//		data, err := gosl.MarshalMsgPack(u)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(data)
+//	}
+func MarshalMsgPack[T any](model *T) ([]byte, error) {
+	w := &msgpackWriter{buf: make([]byte, 0, 64)}
+
+	if err := encodeBinaryValue(w, reflect.ValueOf(model)); err != nil {
+		return nil, err
+	}
+
+	return w.buf, nil
+}
+
+// UnmarshalMsgPack converts MessagePack data (byte slice) to struct *T with the
+// same rules (and the "json" struct tags) as the Unmarshal function.
+//
+// If err != nil returns zero-value for a struct and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	type user struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	func main() {
+//		data := []byte{0x82, 0xa2, 0x69, 0x64, 0x01, 0xa4, 0x6e, 0x61, 0x6d, 0x65, 0xa6, 0x56, 0x69, 0x6b, 0x74, 0x6f, 0x72}
+//		model := &user{}
+//
+//		u, err := gosl.UnmarshalMsgPack(data, model)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(u)
+//	}
+func UnmarshalMsgPack[T any](data []byte, model *T) (*T, error) {
+	r := &msgpackReader{data: data}
+
+	src, err := r.readValue(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check, if data has bytes after the value.
+	if r.pos != len(r.data) {
+		return nil, errors.New("error: invalid MessagePack data, unexpected bytes after top-level value")
+	}
+
+	if err = assignBinaryValue(reflect.ValueOf(&model).Elem(), src); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// msgpackWriter represents a writer of the MessagePack format.
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (w *msgpackWriter) writeNil() {
+	w.buf = append(w.buf, 0xc0)
+}
+
+func (w *msgpackWriter) writeBool(b bool) {
+	if b {
+		w.buf = append(w.buf, 0xc3)
+	} else {
+		w.buf = append(w.buf, 0xc2)
+	}
+}
+
+func (w *msgpackWriter) writeInt(i int64) {
+	switch {
+	case i >= 0:
+		w.writeUint(uint64(i))
+	case i >= -32:
+		w.buf = append(w.buf, byte(int8(i))) // negative fixint
+	case i >= math.MinInt8:
+		w.buf = append(w.buf, 0xd0, byte(int8(i)))
+	case i >= math.MinInt16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xd1), uint16(int16(i)))
+	case i >= math.MinInt32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xd2), uint32(int32(i)))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xd3), uint64(i))
+	}
+}
+
+func (w *msgpackWriter) writeUint(u uint64) {
+	switch {
+	case u <= 0x7f:
+		w.buf = append(w.buf, byte(u)) // positive fixint
+	case u <= math.MaxUint8:
+		w.buf = append(w.buf, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xce), uint32(u))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcf), u)
+	}
+}
+
+func (w *msgpackWriter) writeFloat32(f float32) {
+	w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xca), math.Float32bits(f))
+}
+
+func (w *msgpackWriter) writeFloat64(f float64) {
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcb), math.Float64bits(f))
+}
+
+func (w *msgpackWriter) writeString(s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		w.buf = append(w.buf, 0xa0|byte(n)) // fixstr
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xda), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdb), uint32(n))
+	}
+	w.buf = append(w.buf, s...)
+}
+
+func (w *msgpackWriter) writeBytes(b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xc5), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xc6), uint32(n))
+	}
+	w.buf = append(w.buf, b...)
+}
+
+func (w *msgpackWriter) writeArrayHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x90|byte(n)) // fixarray
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xdc), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdd), uint32(n))
+	}
+}
+
+func (w *msgpackWriter) writeMapHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x80|byte(n)) // fixmap
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xde), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdf), uint32(n))
+	}
+}
+
+// msgpackReader represents a reader of the MessagePack format.
+type msgpackReader struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next n bytes of the data.
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, errBinaryUnexpectedEnd
+	}
+
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+
+	return b, nil
+}
+
+// readLength reads the big-endian length with the given size in bytes.
+func (r *msgpackReader) readLength(size int) (int, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	default:
+		return int(binary.BigEndian.Uint32(b)), nil
+	}
+}
+
+// readValue reads the next value of the data to the generic Go value.
+func (r *msgpackReader) readValue(depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, errors.New("error: invalid MessagePack data, exceeded max depth")
+	}
+
+	b, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil // positive fixint
+	case c >= 0xe0:
+		return int64(int8(c)), nil // negative fixint
+	case c&0xf0 == 0x80:
+		return r.readMap(int(c&0x0f), depth)
+	case c&0xf0 == 0x90:
+		return r.readArray(int(c&0x0f), depth)
+	case c&0xe0 == 0xa0:
+		return r.readString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.readLength(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(nil), p...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.readLength(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+
+		return r.readExt(n)
+	case 0xca:
+		p, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(p))), nil
+	case 0xcb:
+		p, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(p)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		p, err := r.next(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+
+		var u uint64
+		for _, x := range p {
+			u = u<<8 | uint64(x)
+		}
+
+		if u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+
+		return u, nil
+	case 0xd0:
+		p, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return int64(int8(p[0])), nil
+	case 0xd1:
+		p, err := r.next(2)
+		if err != nil {
+			return nil, err
+		}
+
+		return int64(int16(binary.BigEndian.Uint16(p))), nil
+	case 0xd2:
+		p, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+
+		return int64(int32(binary.BigEndian.Uint32(p))), nil
+	case 0xd3:
+		p, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+
+		return int64(binary.BigEndian.Uint64(p)), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return r.readExt(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.readLength(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+
+		return r.readString(n)
+	case 0xdc, 0xdd:
+		n, err := r.readLength(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+
+		return r.readArray(n, depth)
+	case 0xde, 0xdf:
+		n, err := r.readLength(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+
+		return r.readMap(n, depth)
+	default:
+		return nil, fmt.Errorf("error: invalid MessagePack data, unknown format byte 0x%02x", c)
+	}
+}
+
+// readString reads the string with the given length.
+func (r *msgpackReader) readString(n int) (any, error) {
+	p, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(p), nil
+}
+
+// readArray reads the array with the given number of elements.
+func (r *msgpackReader) readArray(n, depth int) (any, error) {
+	// Each element takes at least one byte.
+	if n > len(r.data)-r.pos {
+		return nil, errBinaryUnexpectedEnd
+	}
+
+	a := make([]any, n)
+	for i := range a {
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		a[i] = v
+	}
+
+	return a, nil
+}
+
+// readMap reads the map with the given number of key-value pairs. Keys must
+// be strings or integers.
+func (r *msgpackReader) readMap(n, depth int) (any, error) {
+	// Each key-value pair takes at least two bytes.
+	if n > (len(r.data)-r.pos)/2 {
+		return nil, errBinaryUnexpectedEnd
+	}
+
+	m := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		k, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		var key string
+		switch kv := k.(type) {
+		case string:
+			key = kv
+		case int64:
+			key = strconv.FormatInt(kv, 10)
+		case uint64:
+			key = strconv.FormatUint(kv, 10)
+		default:
+			return nil, fmt.Errorf("error: invalid MessagePack data, unsupported map key type %T", k)
+		}
+
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+
+	return m, nil
+}
+
+// readExt reads the extension with the given data length. Only the timestamp
+// extension (type -1) is supported.
+func (r *msgpackReader) readExt(n int) (any, error) {
+	t, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(t[0]) != -1 {
+		return nil, fmt.Errorf("error: invalid MessagePack data, unsupported extension type %d", int8(t[0]))
+	}
+
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(p)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(p)
+		return time.Unix(int64(v&0x3ffffffff), int64(v>>34)).UTC(), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(p[4:])), int64(binary.BigEndian.Uint32(p))).UTC(), nil
+	default:
+		return nil, errors.New("error: invalid MessagePack data, wrong timestamp extension length")
+	}
+}
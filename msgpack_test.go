@@ -0,0 +1,249 @@
+package gosl
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type binaryAddress struct {
+	City   string   `json:"city"`
+	Street string   `json:"street,omitempty"`
+	Tags   []string `json:"tags"`
+}
+
+type binaryBase struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type binaryModel struct {
+	binaryBase
+	Name     string                    `json:"name"`
+	Password string                    `json:"-"`
+	Age      uint8                     `json:"age"`
+	Score    float64                   `json:"score"`
+	Ratio    float32                   `json:"ratio"`
+	Active   bool                      `json:"active"`
+	Balance  int32                     `json:"balance"`
+	Big      uint64                    `json:"big"`
+	Small    int64                     `json:"small"`
+	Nickname *string                   `json:"nickname"`
+	Note     string                    `json:"note,omitempty"`
+	Address  *binaryAddress            `json:"address"`
+	Contacts []binaryAddress           `json:"contacts"`
+	Counters map[string]int            `json:"counters"`
+	ByID     map[int]string            `json:"by_id"`
+	Extra    map[string]any            `json:"extra"`
+	Matrix   [2][2]int                 `json:"matrix"`
+	Empty    []int                     `json:"empty"`
+	Nested   map[string]*binaryAddress `json:"nested"`
+}
+
+func newBinaryModel() *binaryModel {
+	nickname := "vic"
+
+	return &binaryModel{
+		binaryBase: binaryBase{ID: 42, CreatedAt: time.Date(2023, 5, 1, 12, 30, 0, 500, time.UTC)},
+		Name:       "Viktor",
+		Password:   "secret",
+		Age:        200,
+		Score:      -1234.5678,
+		Ratio:      0.25,
+		Active:     true,
+		Balance:    -70000,
+		Big:        math.MaxUint64,
+		Small:      math.MinInt64,
+		Nickname:   &nickname,
+		Address:    &binaryAddress{City: "Moscow", Tags: []string{"home", "main"}},
+		Contacts:   []binaryAddress{{City: "Berlin", Street: "Unter den Linden"}, {City: "Paris"}},
+		Counters:   map[string]int{"a": 1, "b": -200, "c": 70000},
+		ByID:       map[int]string{1: "one", -2: "minus two"},
+		Extra:      map[string]any{"list": []any{1.0, "two", true, nil}, "object": map[string]any{"x": 1.5}},
+		Matrix:     [2][2]int{{1, 2}, {3, 4}},
+		Nested:     map[string]*binaryAddress{"work": {City: "London"}, "none": nil},
+	}
+}
+
+func BenchmarkMarshalMsgPack_StructField_4(b *testing.B) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"-"`
+	}
+
+	u := &user{ID: 1, Name: "Viktor", Email: "my@mail.com"}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = MarshalMsgPack(u)
+	}
+}
+
+func BenchmarkUnmarshalMsgPack_StructField_4(b *testing.B) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"-"`
+	}
+
+	d, _ := MarshalMsgPack(&user{ID: 1, Name: "Viktor", Email: "my@mail.com"})
+	u := &user{}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = UnmarshalMsgPack(d, u)
+	}
+}
+
+func TestMarshalMsgPack(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"-"`
+	}
+
+	u := &user{ID: 1, Name: "Viktor"}
+	expected := []byte{0x82, 0xa2, 'i', 'd', 0x01, 0xa4, 'n', 'a', 'm', 'e', 0xa6, 'V', 'i', 'k', 't', 'o', 'r'}
+
+	data, err := MarshalMsgPack(u)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, data)
+
+	data, err = MarshalMsgPack[user](nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, []byte{0xc0}, data)
+
+	_, err = MarshalMsgPack(&struct{ C chan int }{C: make(chan int)})
+	require.Error(t, err)
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	data, err = g.MarshalMsgPack(u)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, data)
+}
+
+func TestMarshalMsgPack_Formats(t *testing.T) {
+	for _, tc := range []struct {
+		value    any
+		expected []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0xcc, 0x80}},
+		{256, []byte{0xcd, 0x01, 0x00}},
+		{65536, []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		{uint64(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{-1, []byte{0xff}},
+		{-32, []byte{0xe0}},
+		{-33, []byte{0xd0, 0xdf}},
+		{-129, []byte{0xd1, 0xff, 0x7f}},
+		{-32769, []byte{0xd2, 0xff, 0xff, 0x7f, 0xff}},
+		{int64(math.MinInt64), []byte{0xd3, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{float32(1.5), []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}},
+		{1.5, []byte{0xcb, 0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{true, []byte{0xc3}},
+		{false, []byte{0xc2}},
+		{"", []byte{0xa0}},
+		{[]byte{1, 2}, []byte{0xc4, 0x02, 0x01, 0x02}},
+		{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+	} {
+		data, err := MarshalMsgPack(&tc.value)
+		require.NoError(t, err)
+		assert.EqualValues(t, tc.expected, data, "value %v", tc.value)
+	}
+}
+
+func TestUnmarshalMsgPack(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"-"`
+	}
+
+	data := []byte{0x82, 0xa2, 'i', 'd', 0x01, 0xa4, 'n', 'a', 'm', 'e', 0xa6, 'V', 'i', 'k', 't', 'o', 'r'}
+
+	_, err := UnmarshalMsgPack(nil, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalMsgPack(data[:len(data)-1], &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalMsgPack(append(data, 0x00), &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalMsgPack([]byte{0xc1}, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalMsgPack([]byte{0x81, 0xa2, 'i', 'd', 0xa1, 'x'}, &user{})
+	require.Error(t, err)
+
+	_, err = UnmarshalMsgPack([]byte{0xdd, 0xff, 0xff, 0xff, 0xff}, &user{})
+	require.Error(t, err)
+
+	u, err := UnmarshalMsgPack(data, &user{})
+	require.NoError(t, err)
+	assert.EqualValues(t, &user{ID: 1, Name: "Viktor"}, u)
+
+	g := GenericUtility[user, any]{} // tests for method
+
+	_, err = g.UnmarshalMsgPack(nil, &user{})
+	require.Error(t, err)
+
+	u, err = g.UnmarshalMsgPack(data, &user{})
+	require.NoError(t, err)
+	assert.EqualValues(t, &user{ID: 1, Name: "Viktor"}, u)
+}
+
+func TestUnmarshalMsgPack_Overflow(t *testing.T) {
+	type counter struct {
+		Value int8 `json:"value"`
+	}
+
+	data, err := MarshalMsgPack(&map[string]int{"value": 300})
+	require.NoError(t, err)
+
+	_, err = UnmarshalMsgPack(data, &counter{})
+	require.Error(t, err)
+}
+
+func TestUnmarshalMsgPack_Timestamp(t *testing.T) {
+	var v any
+
+	// Timestamp 32 extension with 1 second since the Unix epoch.
+	out, err := UnmarshalMsgPack([]byte{0xd6, 0xff, 0x00, 0x00, 0x00, 0x01}, &v)
+	require.NoError(t, err)
+	assert.EqualValues(t, time.Unix(1, 0).UTC(), *out)
+}
+
+func TestMsgPack_RoundTripJSON(t *testing.T) {
+	m := newBinaryModel()
+
+	data, err := MarshalMsgPack(m)
+	require.NoError(t, err)
+
+	out, err := UnmarshalMsgPack(data, &binaryModel{})
+	require.NoError(t, err)
+
+	expected, err := Marshal(m)
+	require.NoError(t, err)
+
+	actual, err := Marshal(out)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Empty(t, out.Password)
+
+	// Decode into the generic map, like the Unmarshal function does.
+	fromBinary, err := UnmarshalMsgPack(data, &map[string]any{})
+	require.NoError(t, err)
+
+	fromJSON, err := Unmarshal(expected, &map[string]any{})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, *fromJSON, *fromBinary)
+}
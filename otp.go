@@ -0,0 +1,477 @@
+package gosl
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is the default algorithm of the RFC 4226
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTPAlgorithm represents a hash algorithm of the one-time passwords.
+type OTPAlgorithm uint8
+
+// Hash algorithms of the one-time passwords.
+const (
+	// OTPAlgorithmSHA1 uses the HMAC-SHA-1 (by default).
+	OTPAlgorithmSHA1 OTPAlgorithm = iota
+
+	// OTPAlgorithmSHA256 uses the HMAC-SHA-256.
+	OTPAlgorithmSHA256
+
+	// OTPAlgorithmSHA512 uses the HMAC-SHA-512.
+	OTPAlgorithmSHA512
+)
+
+// String returns the name of the algorithm for the otpauth:// URIs (for ex.,
+// "SHA1").
+func (a OTPAlgorithm) String() string {
+	switch a {
+	case OTPAlgorithmSHA1:
+		return "SHA1"
+	case OTPAlgorithmSHA256:
+		return "SHA256"
+	case OTPAlgorithmSHA512:
+		return "SHA512"
+	default:
+		return "OTPAlgorithm(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// OTPOptions represents options for the one-time passwords.
+type OTPOptions struct {
+	// Digits sets the number of digits of the password from 6 to 10 (6 by
+	// default).
+	Digits int
+
+	// Period sets the time step of the TOTP in whole seconds (30 seconds by
+	// default).
+	Period time.Duration
+
+	// Algorithm sets the hash algorithm (OTPAlgorithmSHA1 by default).
+	Algorithm OTPAlgorithm
+
+	// Skew sets the number of time steps before and after the current one for
+	// the TOTP validation, or the number of counters after the current one for
+	// the HOTP validation (0 by default).
+	Skew int
+}
+
+// otpSecretEncoding represents the Base32 encoding of the secrets (without
+// padding, like the authenticator apps use).
+var otpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateOTPSecret generates a random secret for the one-time passwords with
+// the given number of bytes (20 by default) in the Base32 encoding without
+// padding using built-in "crypto/rand" package.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		secret, err := gosl.GenerateOTPSecret(0)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(secret) // like "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+//	}
+func GenerateOTPSecret(size int) (string, error) {
+	return defaultGenerator.GenerateOTPSecret(size)
+}
+
+// GenerateHOTP generates the counter-based one-time password (RFC 4226) with
+// the given secret in the Base32 encoding.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		code, err := gosl.GenerateHOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", 1)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(code) // 287082
+//	}
+func GenerateHOTP(secret string, counter uint64, opts ...OTPOptions) (string, error) {
+	key, o, err := parseOTPParams(secret, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, counter, o), nil
+}
+
+// ValidateHOTP checks, if the given code is the valid counter-based one-time
+// password (RFC 4226) for the counter or one of the next counters within the
+// skew window (see OTPOptions). Returns the next counter (after the matched
+// one), which must be stored for the next validation.
+//
+// If err != nil returns zero-value for an uint64, false and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		next, ok, err := gosl.ValidateHOTP(
+//			"287082", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", 0,
+//			gosl.OTPOptions{Skew: 2},
+//		)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(next, ok) // 2 true
+//	}
+func ValidateHOTP(code, secret string, counter uint64, opts ...OTPOptions) (next uint64, ok bool, err error) {
+	key, o, err := parseOTPParams(secret, opts)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for i := 0; i <= o.Skew; i++ {
+		if otpEqual(code, hotp(key, counter+uint64(i), o)) {
+			return counter + uint64(i) + 1, true, nil
+		}
+	}
+
+	return counter, false, nil
+}
+
+// GenerateTOTP generates the time-based one-time password (RFC 6238) for the
+// given time with the secret in the Base32 encoding.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//		"time"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		code, err := gosl.GenerateTOTP("JBSWY3DPEHPK3PXP", time.Now())
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(code)
+//	}
+func GenerateTOTP(secret string, t time.Time, opts ...OTPOptions) (string, error) {
+	key, o, err := parseOTPParams(secret, opts)
+	if err != nil {
+		return "", err
+	}
+
+	counter, err := totpCounter(t, o)
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, counter, o), nil
+}
+
+// ValidateTOTP checks, if the given code is the valid time-based one-time
+// password (RFC 6238) for the given time or one of the time steps within the
+// skew window (see OTPOptions).
+//
+// If err != nil returns false and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//		"time"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		ok, err := gosl.ValidateTOTP("123456", "JBSWY3DPEHPK3PXP", time.Now(), gosl.OTPOptions{Skew: 1})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(ok)
+//	}
+func ValidateTOTP(code, secret string, t time.Time, opts ...OTPOptions) (bool, error) {
+	key, o, err := parseOTPParams(secret, opts)
+	if err != nil {
+		return false, err
+	}
+
+	counter, err := totpCounter(t, o)
+	if err != nil {
+		return false, err
+	}
+
+	for i := -o.Skew; i <= o.Skew; i++ {
+		if i < 0 && counter < uint64(-i) {
+			continue // before the Unix epoch
+		}
+
+		if otpEqual(code, hotp(key, counter+uint64(i), o)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TOTPAuthURI returns the otpauth:// provisioning URI (for the QR codes of the
+// authenticator apps) of the time-based one-time passwords with the given
+// secret in the Base32 encoding, issuer and account name.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		uri, err := gosl.TOTPAuthURI("JBSWY3DPEHPK3PXP", "My App", "user@example.com")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(uri)
+//		// otpauth://totp/My%20App:user@example.com?algorithm=SHA1&digits=6&issuer=My+App&period=30&secret=JBSWY3DPEHPK3PXP
+//	}
+func TOTPAuthURI(secret, issuer, account string, opts ...OTPOptions) (string, error) {
+	return otpAuthURI("totp", secret, issuer, account, nil, opts)
+}
+
+// HOTPAuthURI returns the otpauth:// provisioning URI (for the QR codes of the
+// authenticator apps) of the counter-based one-time passwords with the given
+// secret in the Base32 encoding, issuer, account name and initial counter.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		uri, err := gosl.HOTPAuthURI("JBSWY3DPEHPK3PXP", "My App", "user@example.com", 0)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(uri)
+//		// otpauth://hotp/My%20App:user@example.com?algorithm=SHA1&counter=0&digits=6&issuer=My+App&secret=JBSWY3DPEHPK3PXP
+//	}
+func HOTPAuthURI(secret, issuer, account string, counter uint64, opts ...OTPOptions) (string, error) {
+	return otpAuthURI("hotp", secret, issuer, account, &counter, opts)
+}
+
+// GenerateOTPSecret generates a random secret for the one-time passwords with
+// the given number of bytes (20 by default) in the Base32 encoding without
+// padding.
+//
+// If err != nil returns zero-value for a string and error.
+func (g *Generator) GenerateOTPSecret(size int) (string, error) {
+	if size == 0 {
+		size = 20
+	}
+	if size < 10 {
+		return "", fmt.Errorf("error: secret must have at least 10 bytes (RFC 4226), but has %d", size)
+	}
+
+	key := make([]byte, size)
+	if _, err := io.ReadFull(g.r, key); err != nil {
+		return "", fmt.Errorf("can't generate secret, %w", err)
+	}
+
+	return otpSecretEncoding.EncodeToString(key), nil
+}
+
+// parseOTPParams decodes the secret and returns options with the default
+// values.
+func parseOTPParams(secret string, opts []OTPOptions) ([]byte, OTPOptions, error) {
+	var o OTPOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if o.Digits == 0 {
+		o.Digits = 6
+	}
+	if o.Period == 0 {
+		o.Period = 30 * time.Second
+	}
+
+	switch {
+	case o.Digits < 6 || o.Digits > 10:
+		return nil, o, fmt.Errorf("error: number of digits must be from 6 to 10, but is %d", o.Digits)
+	case o.Period < time.Second:
+		return nil, o, fmt.Errorf("error: period must be at least 1 second, but is %s", o.Period)
+	case o.Period%time.Second != 0:
+		return nil, o, fmt.Errorf("error: period must be a whole number of seconds, but is %s", o.Period)
+	case o.Algorithm > OTPAlgorithmSHA512:
+		return nil, o, fmt.Errorf("error: unknown hash algorithm (%s)", o.Algorithm)
+	case o.Skew < 0:
+		return nil, o, fmt.Errorf("error: skew must not be negative, but is %d", o.Skew)
+	}
+
+	// Secrets are often shown in groups with spaces and in lowercase.
+	normalized := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	if normalized == "" {
+		return nil, o, errors.New("error: secret is empty")
+	}
+
+	if !isValidBase32Len(len(normalized)) {
+		return nil, o, fmt.Errorf("can't decode secret, invalid length %d", len(normalized))
+	}
+
+	key, err := otpSecretEncoding.DecodeString(normalized)
+	if err != nil {
+		return nil, o, fmt.Errorf("can't decode secret, %w", err)
+	}
+
+	return key, o, nil
+}
+
+// hotp returns the one-time password for the key and counter (RFC 4226).
+func hotp(key []byte, counter uint64, o OTPOptions) string {
+	var newHash func() hash.Hash
+	switch o.Algorithm {
+	case OTPAlgorithmSHA256:
+		newHash = sha256.New
+	case OTPAlgorithmSHA512:
+		newHash = sha512.New
+	default:
+		newHash = sha1.New
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(newHash, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation of the hash to the 31-bit number.
+	offset := sum[len(sum)-1] & 0x0f
+	code := uint64(binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff)
+
+	mod := uint64(1)
+	for i := 0; i < o.Digits; i++ {
+		mod *= 10
+	}
+
+	s := strconv.FormatUint(code%mod, 10)
+
+	return strings.Repeat("0", o.Digits-len(s)) + s
+}
+
+// totpCounter returns the counter of the time step for the given time.
+func totpCounter(t time.Time, o OTPOptions) (uint64, error) {
+	if t.Unix() < 0 {
+		return 0, fmt.Errorf("error: time %s is before the Unix epoch", t)
+	}
+
+	return uint64(t.Unix()) / uint64(o.Period/time.Second), nil
+}
+
+// otpEqual compares the one-time passwords in the constant time.
+func otpEqual(code, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(code), []byte(expected)) == 1
+}
+
+// otpAuthURI returns the otpauth:// provisioning URI with the given type.
+func otpAuthURI(kind, secret, issuer, account string, counter *uint64, opts []OTPOptions) (string, error) {
+	key, o, err := parseOTPParams(secret, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if account == "" {
+		return "", errors.New("error: account name is empty")
+	}
+	if strings.Contains(issuer, ":") || strings.Contains(account, ":") {
+		return "", errors.New("error: issuer and account name must not contain colons")
+	}
+
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	params := url.Values{}
+	params.Set("secret", otpSecretEncoding.EncodeToString(key))
+	params.Set("algorithm", o.Algorithm.String())
+	params.Set("digits", strconv.Itoa(o.Digits))
+
+	if issuer != "" {
+		params.Set("issuer", issuer)
+	}
+	if counter != nil {
+		params.Set("counter", strconv.FormatUint(*counter, 10))
+	} else {
+		params.Set("period", strconv.Itoa(int(o.Period/time.Second)))
+	}
+
+	return "otpauth://" + kind + "/" + label + "?" + params.Encode(), nil
+}
@@ -0,0 +1,292 @@
+package gosl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Secrets of the RFC 4226 and RFC 6238 test vectors in the Base32 encoding.
+var (
+	otpSecretSHA1   = otpSecretEncoding.EncodeToString([]byte("12345678901234567890"))
+	otpSecretSHA256 = otpSecretEncoding.EncodeToString([]byte("12345678901234567890123456789012"))
+	otpSecretSHA512 = otpSecretEncoding.EncodeToString([]byte(
+		"1234567890123456789012345678901234567890123456789012345678901234",
+	))
+)
+
+func BenchmarkGenerateTOTP(b *testing.B) {
+	now := time.Now()
+
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = GenerateTOTP(otpSecretSHA1, now)
+	}
+	resultGenerators = r
+}
+
+func TestGenerateHOTP(t *testing.T) {
+	// Test vectors from the RFC 4226 (appendix D).
+	for counter, expected := range []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	} {
+		code, err := GenerateHOTP(otpSecretSHA1, uint64(counter))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "counter %d", counter)
+	}
+
+	code, err := GenerateHOTP(otpSecretSHA1, 0, OTPOptions{Digits: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "1284755224", code)
+
+	for _, opts := range []OTPOptions{
+		{Digits: 5}, {Digits: 11}, {Algorithm: 3}, {Period: time.Millisecond}, {Period: 1500 * time.Millisecond}, {Skew: -1},
+	} {
+		_, err = GenerateHOTP(otpSecretSHA1, 0, opts)
+		require.Error(t, err, "options %+v", opts)
+	}
+
+	for _, secret := range []string{"", "1", "GEZ", "GEZDGNBV1"} {
+		_, err = GenerateHOTP(secret, 0)
+		require.Error(t, err, "secret %s", secret)
+	}
+
+	// Secrets in lowercase, with spaces and padding.
+	code, err = GenerateHOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq====", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	g := Utility{} // tests for method
+
+	code, err = g.GenerateHOTP(otpSecretSHA1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestValidateHOTP(t *testing.T) {
+	next, ok, err := ValidateHOTP("755224", otpSecretSHA1, 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, next)
+
+	// Codes of the next counters are valid only within the skew window.
+	next, ok, err = ValidateHOTP("969429", otpSecretSHA1, 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.EqualValues(t, 0, next)
+
+	next, ok, err = ValidateHOTP("969429", otpSecretSHA1, 0, OTPOptions{Skew: 3})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, 4, next)
+
+	// Codes of the previous counters are not valid.
+	_, ok, err = ValidateHOTP("755224", otpSecretSHA1, 1, OTPOptions{Skew: 3})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = ValidateHOTP("75522", otpSecretSHA1, 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = ValidateHOTP("755224", "", 0)
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	next, ok, err = g.ValidateHOTP("287082", otpSecretSHA1, 0, OTPOptions{Skew: 1})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, next)
+}
+
+func TestGenerateTOTP(t *testing.T) {
+	// Test vectors from the RFC 6238 (appendix B).
+	for _, tc := range []struct {
+		unix                 int64
+		sha1, sha256, sha512 string
+	}{
+		{59, "94287082", "46119246", "90693936"},
+		{1111111109, "07081804", "68084774", "25091201"},
+		{1111111111, "14050471", "67062674", "99943326"},
+		{1234567890, "89005924", "91819424", "93441116"},
+		{2000000000, "69279037", "90698825", "38618901"},
+		{20000000000, "65353130", "77737706", "47863826"},
+	} {
+		for _, c := range []struct {
+			secret    string
+			algorithm OTPAlgorithm
+			expected  string
+		}{
+			{otpSecretSHA1, OTPAlgorithmSHA1, tc.sha1},
+			{otpSecretSHA256, OTPAlgorithmSHA256, tc.sha256},
+			{otpSecretSHA512, OTPAlgorithmSHA512, tc.sha512},
+		} {
+			code, err := GenerateTOTP(c.secret, time.Unix(tc.unix, 0), OTPOptions{Digits: 8, Algorithm: c.algorithm})
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, code, "time %d, algorithm %s", tc.unix, c.algorithm)
+		}
+	}
+
+	// Custom period.
+	code, err := GenerateTOTP(otpSecretSHA1, time.Unix(119, 0), OTPOptions{Digits: 8, Period: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, "94287082", code)
+
+	// Periods, which aren't whole seconds, can't be used.
+	_, err = GenerateTOTP(otpSecretSHA1, time.Unix(59, 0), OTPOptions{Period: 1500 * time.Millisecond})
+	require.Error(t, err)
+
+	_, err = GenerateTOTP(otpSecretSHA1, time.Unix(-1, 0))
+	require.Error(t, err)
+
+	_, err = GenerateTOTP("", time.Now())
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	code, err = g.GenerateTOTP(otpSecretSHA1, time.Unix(59, 0), OTPOptions{Digits: 8})
+	require.NoError(t, err)
+	assert.Equal(t, "94287082", code)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	opts := OTPOptions{Digits: 8}
+
+	ok, err := ValidateTOTP("94287082", otpSecretSHA1, time.Unix(59, 0), opts)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Codes of the previous and next time steps are valid only within the skew
+	// window.
+	ok, err = ValidateTOTP("94287082", otpSecretSHA1, time.Unix(89, 0), opts)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	opts.Skew = 1
+
+	ok, err = ValidateTOTP("94287082", otpSecretSHA1, time.Unix(89, 0), opts)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = ValidateTOTP("94287082", otpSecretSHA1, time.Unix(5, 0), opts)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = ValidateTOTP("94287082", otpSecretSHA1, time.Unix(120, 0), opts)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = ValidateTOTP("00000000", otpSecretSHA1, time.Unix(59, 0), opts)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = ValidateTOTP("94287082", otpSecretSHA1, time.Unix(-1, 0), opts)
+	require.Error(t, err)
+
+	_, err = ValidateTOTP("94287082", "", time.Unix(59, 0), opts)
+	require.Error(t, err)
+
+	// Generated secrets.
+	secret, err := GenerateOTPSecret(0)
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Now()
+
+	code, err := GenerateTOTP(secret, now)
+	require.NoError(t, err)
+
+	ok, err = ValidateTOTP(code, secret, now.Add(30*time.Second), OTPOptions{Skew: 1})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	g := Utility{} // tests for method
+
+	ok, err = g.ValidateTOTP("94287082", otpSecretSHA1, time.Unix(59, 0), OTPOptions{Digits: 8})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestGenerateOTPSecret(t *testing.T) {
+	secret, err := GenerateOTPSecret(32)
+	require.NoError(t, err)
+
+	key, err := otpSecretEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	_, err = GenerateOTPSecret(9)
+	require.Error(t, err)
+
+	_, err = NewGenerator(iotest.ErrReader(errors.New("failed"))).GenerateOTPSecret(0)
+	require.Error(t, err)
+
+	// The same seed gives the same secret.
+	s1, err := NewGenerator(NewSeededReader(1)).GenerateOTPSecret(0)
+	require.NoError(t, err)
+
+	s2, err := NewGenerator(NewSeededReader(1)).GenerateOTPSecret(0)
+	require.NoError(t, err)
+	assert.Equal(t, s1, s2)
+
+	g := Utility{} // tests for method
+
+	secret, err = g.GenerateOTPSecret(0)
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+}
+
+func TestOTPAuthURI(t *testing.T) {
+	uri, err := TOTPAuthURI("jbswy3dpehpk3pxp", "My App", "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t,
+		"otpauth://totp/My%20App:user@example.com?algorithm=SHA1&digits=6&issuer=My+App&period=30&secret=JBSWY3DPEHPK3PXP",
+		uri,
+	)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/My App:user@example.com", u.Path)
+
+	uri, err = TOTPAuthURI("JBSWY3DPEHPK3PXP", "", "alice", OTPOptions{
+		Digits: 8, Period: time.Minute, Algorithm: OTPAlgorithmSHA256,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth://totp/alice?algorithm=SHA256&digits=8&period=60&secret=JBSWY3DPEHPK3PXP", uri)
+
+	uri, err = HOTPAuthURI("JBSWY3DPEHPK3PXP", "ACME", "bob", 5, OTPOptions{Algorithm: OTPAlgorithmSHA512})
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth://hotp/ACME:bob?algorithm=SHA512&counter=5&digits=6&issuer=ACME&secret=JBSWY3DPEHPK3PXP", uri)
+
+	_, err = TOTPAuthURI("JBSWY3DPEHPK3PXP", "ACME", "bob", OTPOptions{Period: 1500 * time.Millisecond})
+	require.Error(t, err)
+
+	_, err = TOTPAuthURI("", "ACME", "bob")
+	require.Error(t, err)
+
+	_, err = TOTPAuthURI("JBSWY3DPEHPK3PXP", "ACME", "")
+	require.Error(t, err)
+
+	_, err = HOTPAuthURI("JBSWY3DPEHPK3PXP", "ACME:Inc", "bob", 0)
+	require.Error(t, err)
+
+	assert.Equal(t, "OTPAlgorithm(7)", OTPAlgorithm(7).String())
+
+	g := Utility{} // tests for method
+
+	uri, err = g.TOTPAuthURI("JBSWY3DPEHPK3PXP", "", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth://totp/alice?algorithm=SHA1&digits=6&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+
+	uri, err = g.HOTPAuthURI("JBSWY3DPEHPK3PXP", "", "alice", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth://hotp/alice?algorithm=SHA1&counter=0&digits=6&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package gosl
+
+// raceEnabled reports whether tests are running with the race detector, which
+// adds allocations to some functions.
+const raceEnabled = true
@@ -1,6 +1,12 @@
 package gosl
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	jsoniter "github.com/json-iterator/go"
+)
 
 // RenderStyled render a styled string with a given lipgloss.Style template
 // using "charmbracelet/lipgloss" package.
@@ -29,3 +35,79 @@ import "github.com/charmbracelet/lipgloss"
 func RenderStyled(str string, template lipgloss.Style) string {
 	return template.Render(str)
 }
+
+// RenderJSONDiff render a list of the changes from the DiffJSON (or
+// DiffValues) function to the colored human-readable string using
+// "charmbracelet/lipgloss" package.
+//
+// Added values are green (with "+" prefix), removed values are red (with "-"
+// prefix), and modified values are yellow (with "~" prefix).
+//
+// If changes has no elements returns zero-value for a string.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		a := []byte(`{"id":1,"name":"Viktor"}`)
+//		b := []byte(`{"id":2,"email":"my@mail.com"}`)
+//
+//		changes, err := gosl.DiffJSON(a, b)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(gosl.RenderJSONDiff(changes))
+//	}
+func RenderJSONDiff(changes []JSONChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(changes))
+
+	for _, c := range changes {
+		var line string
+
+		switch c.Op {
+		case JSONDiffAdded:
+			line = RenderStyled(Concat("+ ", c.Path, ": ", renderJSONValue(c.NewValue)), jsonDiffAddedStyle)
+		case JSONDiffRemoved:
+			line = RenderStyled(Concat("- ", c.Path, ": ", renderJSONValue(c.OldValue)), jsonDiffRemovedStyle)
+		default:
+			line = RenderStyled(
+				Concat("~ ", c.Path, ": ", renderJSONValue(c.OldValue), " → ", renderJSONValue(c.NewValue)),
+				jsonDiffModifiedStyle,
+			)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Styles of the changes for the RenderJSONDiff function.
+var (
+	jsonDiffAddedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	jsonDiffRemovedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	jsonDiffModifiedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// renderJSONValue renders the value of the change as a compact JSON.
+func renderJSONValue(v any) string {
+	b, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
@@ -32,3 +32,24 @@ func TestRenderStyled(t *testing.T) {
 	r = g.RenderStyled("Hello, World!", lipgloss.NewStyle().Foreground(lipgloss.Color("42")))
 	assert.EqualValues(t, "Hello, World!", r)
 }
+
+func TestRenderJSONDiff(t *testing.T) {
+	changes, err := DiffJSON(
+		[]byte(`{"id":1,"name":"Viktor","tags":["admin"]}`),
+		[]byte(`{"id":2,"name":"Viktor","email":"my@mail.com"}`),
+	)
+	assert.NoError(t, err)
+
+	expected := "+ /email: \"my@mail.com\"\n~ /id: 1 → 2\n- /tags: [\"admin\"]"
+
+	r := RenderJSONDiff(nil)
+	assert.EqualValues(t, "", r)
+
+	r = RenderJSONDiff(changes)
+	assert.EqualValues(t, expected, r)
+
+	g := Utility{} // tests for method
+
+	r = g.RenderJSONDiff(changes)
+	assert.EqualValues(t, expected, r)
+}
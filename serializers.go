@@ -0,0 +1,746 @@
+package gosl
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// jsonNumberAPI represents a jsoniter configuration, compatible with the
+// "encoding/json" standard lib, that decodes numbers into json.Number.
+var jsonNumberAPI = jsoniter.Config{
+	EscapeHTML:             true,
+	SortMapKeys:            true,
+	ValidateJsonRawMessage: true,
+	UseNumber:              true,
+}.Froze()
+
+var (
+	jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	jsonNumberType      = reflect.TypeFor[json.Number]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// structField represents an exported field of the struct, resolved by the
+// given tag name (like "json" or "koanf").
+type structField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	tagged    bool
+}
+
+// structFieldsKey represents a key for the cache of the resolved struct fields.
+type structFieldsKey struct {
+	typ reflect.Type
+	tag string
+}
+
+// structFieldsCache caches the resolved struct fields by type and tag name.
+var structFieldsCache sync.Map
+
+// cachedStructFields returns the resolved fields of the given struct type by
+// the tag name. Fields of the embedded structs without a name in the tag are
+// promoted to the parent struct, like the "encoding/json" standard lib does.
+func cachedStructFields(t reflect.Type, tag string) []structField {
+	key := structFieldsKey{typ: t, tag: tag}
+
+	// Check, if fields of this type are already resolved.
+	if fields, ok := structFieldsCache.Load(key); ok {
+		return fields.([]structField)
+	}
+
+	// Resolve fields and select only the dominant ones.
+	all := make([]structField, 0, t.NumField())
+	resolveStructFields(t, tag, nil, map[reflect.Type]bool{}, &all)
+
+	fields := make([]structField, 0, len(all))
+	for i := range all {
+		dominant := true
+		for j := range all {
+			if i != j && all[i].name == all[j].name {
+				// Shallower field wins, on the same depth the tagged one wins,
+				// otherwise the first one wins.
+				if len(all[j].index) < len(all[i].index) ||
+					(len(all[j].index) == len(all[i].index) && all[j].tagged && !all[i].tagged) ||
+					(len(all[j].index) == len(all[i].index) && all[j].tagged == all[i].tagged && j < i) {
+					dominant = false
+					break
+				}
+			}
+		}
+
+		if dominant {
+			fields = append(fields, all[i])
+		}
+	}
+
+	cached, _ := structFieldsCache.LoadOrStore(key, fields)
+
+	return cached.([]structField)
+}
+
+// resolveStructFields helps to collect fields for the cachedStructFields
+// function.
+func resolveStructFields(t reflect.Type, tag string, parent []int, visited map[reflect.Type]bool, fields *[]structField) {
+	// Check, if this type is already visited (recursive embedding).
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		// Parse the tag value of the field.
+		name, opts, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" && opts == "" {
+			continue // skip ignored field
+		}
+
+		index := append(slices.Clip(parent), i)
+
+		// Check, if field is an embedded struct without a name in the tag.
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				resolveStructFields(ft, tag, index, visited, fields)
+				continue
+			}
+		}
+
+		// Skip unexported fields.
+		if !f.IsExported() {
+			continue
+		}
+
+		field := structField{
+			name:   name,
+			index:  index,
+			typ:    f.Type,
+			tagged: name != "",
+		}
+
+		if field.name == "" {
+			field.name = f.Name
+		}
+
+		for opts != "" {
+			var opt string
+			opt, opts, _ = strings.Cut(opts, ",")
+			if opt == "omitempty" {
+				field.omitEmpty = true
+			}
+		}
+
+		*fields = append(*fields, field)
+	}
+}
+
+// fieldByIndex returns the nested field of the struct by the given index. If
+// alloc is true, nil embedded pointers are allocated, otherwise returns false
+// for a bool.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// isEmptyValue reports whether the value is empty for the "omitempty" option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
+
+// binaryWriter represents an interface for the writers of the binary
+// serialization formats (MessagePack and CBOR).
+type binaryWriter interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(i int64)
+	writeUint(u uint64)
+	writeFloat32(f float32)
+	writeFloat64(f float64)
+	writeString(s string)
+	writeBytes(b []byte)
+	writeArrayHeader(n int)
+	writeMapHeader(n int)
+}
+
+// encodeBinaryValue writes the given value with the binary writer, using the
+// same rules (and the "json" struct tags) as the Marshal function.
+func encodeBinaryValue(w binaryWriter, v reflect.Value) error {
+	// Check, if the value is invalid or nil.
+	if !v.IsValid() {
+		w.writeNil()
+		return nil
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		w.writeNil()
+		return nil
+	}
+
+	// Check, if the value implements one of the marshaler interfaces.
+	if m, ok := implementsInterface(v, jsonMarshalerType); ok {
+		data, err := m.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		var raw any
+		if err = jsonNumberAPI.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+
+		return encodeBinaryValue(w, reflect.ValueOf(raw))
+	}
+
+	if m, ok := implementsInterface(v, textMarshalerType); ok {
+		text, err := m.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+
+		w.writeString(string(text))
+
+		return nil
+	}
+
+	// Check, if the value is a json.Number.
+	if v.Type() == jsonNumberType {
+		return encodeBinaryNumber(w, json.Number(v.String()))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		w.writeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.writeUint(v.Uint())
+	case reflect.Float32:
+		w.writeFloat32(float32(v.Float()))
+	case reflect.Float64:
+		w.writeFloat64(v.Float())
+	case reflect.String:
+		w.writeString(v.String())
+	case reflect.Pointer, reflect.Interface:
+		return encodeBinaryValue(w, v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.writeBytes(v.Bytes())
+			return nil
+		}
+
+		return encodeBinaryArray(w, v)
+	case reflect.Array:
+		return encodeBinaryArray(w, v)
+	case reflect.Map:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+
+		return encodeBinaryMap(w, v)
+	case reflect.Struct:
+		return encodeBinaryStruct(w, v)
+	default:
+		return fmt.Errorf("error: unsupported type %s for binary serialization", v.Type())
+	}
+
+	return nil
+}
+
+// encodeBinaryNumber helps to write json.Number for the encodeBinaryValue
+// function.
+func encodeBinaryNumber(w binaryWriter, n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		w.writeInt(i)
+		return nil
+	}
+
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		w.writeUint(u)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return fmt.Errorf("error: invalid number %q, %w", string(n), err)
+	}
+
+	w.writeFloat64(f)
+
+	return nil
+}
+
+// encodeBinaryArray helps to write slices and arrays for the
+// encodeBinaryValue function.
+func encodeBinaryArray(w binaryWriter, v reflect.Value) error {
+	w.writeArrayHeader(v.Len())
+
+	for i := 0; i < v.Len(); i++ {
+		if err := encodeBinaryValue(w, v.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeBinaryMap helps to write maps for the encodeBinaryValue function. Keys
+// are always written as sorted strings, like the Marshal function does.
+func encodeBinaryMap(w binaryWriter, v reflect.Value) error {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, v.Len())
+
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := mapKeyToString(iter.Key())
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	w.writeMapHeader(len(entries))
+
+	for _, e := range entries {
+		w.writeString(e.key)
+
+		if err := encodeBinaryValue(w, e.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeBinaryStruct helps to write structs for the encodeBinaryValue
+// function.
+func encodeBinaryStruct(w binaryWriter, v reflect.Value) error {
+	fields := cachedStructFields(v.Type(), "json")
+
+	// Collect values of the fields to know the size of the map.
+	names := make([]string, 0, len(fields))
+	values := make([]reflect.Value, 0, len(fields))
+
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index, false)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		names = append(names, f.name)
+		values = append(values, fv)
+	}
+
+	w.writeMapHeader(len(names))
+
+	for i := range names {
+		w.writeString(names[i])
+
+		if err := encodeBinaryValue(w, values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapKeyToString converts the key of the map to string, like the Marshal
+// function does.
+func mapKeyToString(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+
+	if m, ok := implementsInterface(k, textMarshalerType); ok {
+		text, err := m.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", err
+		}
+
+		return string(text), nil
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("error: unsupported map key type %s", k.Type())
+	}
+}
+
+// implementsInterface reports whether the value (or the pointer to the
+// addressable value) implements the given interface type.
+func implementsInterface(v reflect.Value, iface reflect.Type) (reflect.Value, bool) {
+	if v.Type().Implements(iface) {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return reflect.Value{}, false
+		}
+		return v, true
+	}
+
+	if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(iface) {
+		return v.Addr(), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// errBinaryAssign creates an error for the assignBinaryValue function.
+func errBinaryAssign(src any, t reflect.Type) error {
+	return fmt.Errorf("error: can't unmarshal %T into Go value of type %s", src, t)
+}
+
+// assignBinaryValue assigns the decoded value (nil, bool, int64, uint64,
+// float64, string, []byte, time.Time, []any or map[string]any) to the given
+// settable value, using the same rules (and the "json" struct tags) as the
+// Unmarshal function.
+func assignBinaryValue(dst reflect.Value, src any) error {
+	// Check, if the decoded value is nil.
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			dst.Set(reflect.Zero(dst.Type()))
+		}
+		return nil
+	}
+
+	// Allocate and dereference pointers.
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assignBinaryValue(dst.Elem(), src)
+	}
+
+	// Check, if the value implements one of the unmarshaler interfaces.
+	if u, ok := implementsInterface(dst, jsonUnmarshalerType); ok {
+		data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(src)
+		if err != nil {
+			return err
+		}
+
+		return u.Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+
+	if t, ok := src.(time.Time); ok && dst.Type() == timeType {
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if u, ok := implementsInterface(dst, textUnmarshalerType); ok {
+		switch s := src.(type) {
+		case string:
+			return u.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		case []byte:
+			return u.Interface().(encoding.TextUnmarshaler).UnmarshalText(s)
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return errBinaryAssign(src, dst.Type())
+		}
+		dst.Set(reflect.ValueOf(normalizeBinaryValue(src)))
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return errBinaryAssign(src, dst.Type())
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch n := src.(type) {
+		case int64:
+			i = n
+		case uint64:
+			if n > math.MaxInt64 {
+				return errBinaryAssign(src, dst.Type())
+			}
+			i = int64(n)
+		case float64:
+			if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+				return errBinaryAssign(src, dst.Type())
+			}
+			i = int64(n)
+		default:
+			return errBinaryAssign(src, dst.Type())
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("error: number %d overflows Go value of type %s", i, dst.Type())
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch n := src.(type) {
+		case int64:
+			if n < 0 {
+				return errBinaryAssign(src, dst.Type())
+			}
+			u = uint64(n)
+		case uint64:
+			u = n
+		case float64:
+			if n != math.Trunc(n) || n < 0 || n >= math.MaxUint64 {
+				return errBinaryAssign(src, dst.Type())
+			}
+			u = uint64(n)
+		default:
+			return errBinaryAssign(src, dst.Type())
+		}
+		if dst.OverflowUint(u) {
+			return fmt.Errorf("error: number %d overflows Go value of type %s", u, dst.Type())
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch n := src.(type) {
+		case int64:
+			f = float64(n)
+		case uint64:
+			f = float64(n)
+		case float64:
+			f = n
+		default:
+			return errBinaryAssign(src, dst.Type())
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("error: number %g overflows Go value of type %s", f, dst.Type())
+		}
+		dst.SetFloat(f)
+	case reflect.String:
+		switch s := src.(type) {
+		case string:
+			dst.SetString(s)
+		case []byte:
+			dst.SetString(string(s))
+		default:
+			return errBinaryAssign(src, dst.Type())
+		}
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			switch b := src.(type) {
+			case []byte:
+				dst.SetBytes(slices.Clone(b))
+				return nil
+			case string:
+				dst.SetBytes([]byte(b))
+				return nil
+			}
+		}
+
+		a, ok := src.([]any)
+		if !ok {
+			return errBinaryAssign(src, dst.Type())
+		}
+
+		s := reflect.MakeSlice(dst.Type(), len(a), len(a))
+		for i := range a {
+			if err := assignBinaryValue(s.Index(i), a[i]); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case reflect.Array:
+		a, ok := src.([]any)
+		if !ok {
+			return errBinaryAssign(src, dst.Type())
+		}
+
+		for i := 0; i < dst.Len(); i++ {
+			if i >= len(a) {
+				dst.Index(i).SetZero()
+				continue
+			}
+			if err := assignBinaryValue(dst.Index(i), a[i]); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return errBinaryAssign(src, dst.Type())
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), len(m)))
+		}
+
+		for k, v := range m {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := stringToMapKey(key, k); err != nil {
+				return err
+			}
+
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignBinaryValue(value, v); err != nil {
+				return err
+			}
+
+			dst.SetMapIndex(key, value)
+		}
+	case reflect.Struct:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return errBinaryAssign(src, dst.Type())
+		}
+
+		fields := cachedStructFields(dst.Type(), "json")
+
+		for k, v := range m {
+			f := findStructField(fields, k)
+			if f == nil {
+				continue // skip unknown keys
+			}
+
+			fv, ok := fieldByIndex(dst, f.index, true)
+			if !ok {
+				return fmt.Errorf("error: can't set embedded pointer to unexported struct for field %s of type %s", f.name, dst.Type())
+			}
+
+			if err := assignBinaryValue(fv, v); err != nil {
+				return err
+			}
+		}
+	default:
+		return errBinaryAssign(src, dst.Type())
+	}
+
+	return nil
+}
+
+// normalizeBinaryValue converts the decoded value to the type, which the
+// Unmarshal function produces for the interface values (all numbers are
+// float64).
+func normalizeBinaryValue(src any) any {
+	switch v := src.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case []any:
+		for i := range v {
+			v[i] = normalizeBinaryValue(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = normalizeBinaryValue(v[k])
+		}
+	}
+
+	return src
+}
+
+// findStructField returns the struct field by the exact name, otherwise by
+// the case-insensitive name, like the Unmarshal function does.
+func findStructField(fields []structField, name string) *structField {
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, name) {
+			return &fields[i]
+		}
+	}
+
+	return nil
+}
+
+// stringToMapKey sets the string key to the given map key value, like the
+// Unmarshal function does.
+func stringToMapKey(dst reflect.Value, s string) error {
+	if u, ok := implementsInterface(dst, textUnmarshalerType); ok {
+		return u.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || dst.OverflowInt(i) {
+			return fmt.Errorf("error: can't unmarshal map key %q into Go value of type %s", s, dst.Type())
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || dst.OverflowUint(u) {
+			return fmt.Errorf("error: can't unmarshal map key %q into Go value of type %s", s, dst.Type())
+		}
+		dst.SetUint(u)
+	default:
+		return fmt.Errorf("error: unsupported map key type %s", dst.Type())
+	}
+
+	return nil
+}
+
+// errBinaryUnexpectedEnd represents an error for the truncated binary data.
+var errBinaryUnexpectedEnd = errors.New("error: unexpected end of binary data")
+
+// maxBinaryDepth represents the maximum nesting depth of the binary data.
+const maxBinaryDepth = 10000
@@ -0,0 +1,51 @@
+package gosl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type binaryInner struct {
+	X int `json:"x"`
+}
+
+func TestUnmarshalBinary_EmbeddedPointer(t *testing.T) {
+	type outer struct {
+		*binaryInner
+		Y int `json:"y"`
+	}
+
+	m := map[string]int{"x": 1, "y": 2}
+
+	msgpack, err := MarshalMsgPack(&m)
+	require.NoError(t, err)
+
+	cbor, err := MarshalCBOR(&m)
+	require.NoError(t, err)
+
+	// Nil embedded pointer to the unexported struct can't be allocated.
+	_, err = UnmarshalMsgPack(msgpack, &outer{})
+	require.Error(t, err)
+
+	_, err = UnmarshalCBOR(cbor, &outer{})
+	require.Error(t, err)
+
+	// Already allocated embedded pointer is filled.
+	o, err := UnmarshalMsgPack(msgpack, &outer{binaryInner: &binaryInner{}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, o.X)
+	assert.Equal(t, 2, o.Y)
+
+	o, err = UnmarshalCBOR(cbor, &outer{binaryInner: &binaryInner{}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, o.X)
+	assert.Equal(t, 2, o.Y)
+
+	// Without keys of the embedded struct, the pointer stays nil.
+	o, err = UnmarshalMsgPack([]byte{0x81, 0xa1, 'y', 0x02}, &outer{})
+	require.NoError(t, err)
+	assert.Nil(t, o.binaryInner)
+	assert.Equal(t, 2, o.Y)
+}
@@ -0,0 +1,208 @@
+package gosl
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClockRollback represents an error of the Snowflake generator, when the
+// clock goes back for longer than the allowed waiting time.
+var ErrClockRollback = errors.New("clock moved backwards")
+
+// SnowflakeOptions represents options for the Snowflake generator.
+type SnowflakeOptions struct {
+	// Epoch sets the start time of the timestamps (2020-01-01 UTC by default).
+	Epoch time.Time
+
+	// NodeBits sets the number of bits for the node ID (10 by default).
+	NodeBits uint8
+
+	// SequenceBits sets the number of bits for the sequence number within the
+	// same millisecond (12 by default).
+	SequenceBits uint8
+
+	// RollbackWait sets the maximum time to wait, when the clock goes back
+	// (for ex., after the NTP sync). If the clock goes back for longer, the
+	// Next method returns ErrClockRollback (immediately by default).
+	RollbackWait time.Duration
+
+	// Now sets a function, which returns the current time (time.Now by
+	// default).
+	Now func() time.Time
+}
+
+// Snowflake represents a generator of the sortable 64-bit IDs (like the
+// Twitter's Snowflake), which consist of the timestamp in milliseconds since
+// the epoch, node ID and sequence number within the same millisecond.
+//
+// Snowflake is safe for concurrent use by multiple goroutines (without locks).
+type Snowflake struct {
+	epoch        time.Time
+	now          func() time.Time
+	rollbackWait time.Duration
+	node         int64
+	nodeBits     uint8
+	sequenceBits uint8
+
+	// state represents the timestamp and sequence number of the last ID.
+	state atomic.Uint64
+}
+
+// SnowflakeParts represents parts of the Snowflake ID.
+type SnowflakeParts struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// snowflakeEpoch represents the default epoch of the Snowflake IDs.
+var snowflakeEpoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NewSnowflake creates a new Snowflake generator with the given node ID, which
+// must be unique for each instance of the service.
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.NewSnowflake(1)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		id, err := s.Next()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(id, s.Decode(id).Node) // like "... 1"
+//	}
+func NewSnowflake(node int64, opts ...SnowflakeOptions) (*Snowflake, error) {
+	s := &Snowflake{
+		epoch:        snowflakeEpoch,
+		now:          time.Now,
+		node:         node,
+		nodeBits:     10,
+		sequenceBits: 12,
+	}
+
+	if len(opts) > 0 {
+		o := opts[0]
+
+		if !o.Epoch.IsZero() {
+			s.epoch = o.Epoch
+		}
+		if o.NodeBits > 0 {
+			s.nodeBits = o.NodeBits
+		}
+		if o.SequenceBits > 0 {
+			s.sequenceBits = o.SequenceBits
+		}
+		if o.Now != nil {
+			s.now = o.Now
+		}
+
+		s.rollbackWait = o.RollbackWait
+	}
+
+	if bits := s.nodeBits + s.sequenceBits; bits > 24 {
+		return nil, fmt.Errorf("error: node and sequence bits must be at most 24 in total (39 bits for the timestamp), but are %d", bits)
+	}
+	if node < 0 || node >= 1<<s.nodeBits {
+		return nil, fmt.Errorf("error: node ID must be from 0 to %d, but is %d", 1<<s.nodeBits-1, node)
+	}
+	if s.epoch.After(s.now()) {
+		return nil, fmt.Errorf("error: epoch %s is in the future", s.epoch)
+	}
+
+	return s, nil
+}
+
+// Next generates the next Snowflake ID, which is greater than all previous IDs
+// of this generator.
+//
+// If the clock goes back, waits for it (see SnowflakeOptions) or returns
+// ErrClockRollback. If the sequence numbers of the current millisecond are
+// exhausted, waits for the next millisecond.
+//
+// If err != nil returns zero-value for an int64 and error.
+func (s *Snowflake) Next() (int64, error) {
+	sequenceMask := uint64(1)<<s.sequenceBits - 1
+	timeBits := 63 - s.nodeBits - s.sequenceBits
+
+	for {
+		// Load the state before reading the clock, otherwise the state can be
+		// updated by another goroutine with a later time in between, which
+		// looks like the clock rollback.
+		old := s.state.Load()
+		last, sequence := old>>s.sequenceBits, old&sequenceMask
+
+		ms, err := s.elapsed()
+		if err != nil {
+			return 0, err
+		}
+		if ms >= 1<<timeBits {
+			return 0, fmt.Errorf("error: timestamp of the Snowflake ID overflows %d bits, use a later epoch", timeBits)
+		}
+
+		var next uint64
+		switch {
+		case ms > last:
+			next = ms << s.sequenceBits
+		case ms == last && sequence < sequenceMask:
+			next = old + 1
+		case ms == last:
+			runtime.Gosched() // wait for the next millisecond
+			continue
+		default:
+			rollback := time.Duration(last-ms) * time.Millisecond
+			if rollback > s.rollbackWait {
+				return 0, fmt.Errorf("error: can't generate Snowflake ID, %w by %s", ErrClockRollback, rollback)
+			}
+
+			time.Sleep(rollback)
+			continue
+		}
+
+		if s.state.CompareAndSwap(old, next) {
+			ms, sequence = next>>s.sequenceBits, next&sequenceMask
+
+			return int64(ms<<(s.nodeBits+s.sequenceBits) | uint64(s.node)<<s.sequenceBits | sequence), nil
+		}
+	}
+}
+
+// Decode returns parts (time, node ID and sequence number) of the given
+// Snowflake ID, generated with the same options.
+func (s *Snowflake) Decode(id int64) SnowflakeParts {
+	u := uint64(id)
+
+	return SnowflakeParts{
+		Time:     s.epoch.Add(time.Duration(u>>(s.nodeBits+s.sequenceBits)) * time.Millisecond),
+		Node:     int64(u >> s.sequenceBits & (1<<s.nodeBits - 1)),
+		Sequence: int64(u & (1<<s.sequenceBits - 1)),
+	}
+}
+
+// elapsed returns the number of milliseconds since the epoch.
+func (s *Snowflake) elapsed() (uint64, error) {
+	ms := s.now().Sub(s.epoch).Milliseconds()
+	if ms < 0 {
+		return 0, fmt.Errorf("error: can't generate Snowflake ID, %w before the epoch", ErrClockRollback)
+	}
+
+	return uint64(ms), nil
+}
@@ -0,0 +1,242 @@
+package gosl
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resultSnowflake int64
+
+func BenchmarkSnowflake_Next(b *testing.B) {
+	s, _ := NewSnowflake(1)
+
+	var r int64
+	for i := 0; i < b.N; i++ {
+		r, _ = s.Next()
+	}
+	resultSnowflake = r
+}
+
+func BenchmarkSnowflake_NextParallel(b *testing.B) {
+	s, _ := NewSnowflake(1)
+
+	b.RunParallel(func(pb *testing.PB) {
+		var r int64
+		for pb.Next() {
+			r, _ = s.Next()
+		}
+		atomic.StoreInt64(&resultSnowflake, r)
+	})
+}
+
+func TestNewSnowflake(t *testing.T) {
+	s, err := NewSnowflake(42)
+	require.NoError(t, err)
+
+	prev := int64(0)
+	for i := 0; i < 10000; i++ {
+		id, err := s.Next()
+		require.NoError(t, err)
+		require.Greater(t, id, prev)
+		prev = id
+	}
+
+	parts := s.Decode(prev)
+	assert.EqualValues(t, 42, parts.Node)
+	assert.WithinDuration(t, time.Now(), parts.Time, time.Minute)
+
+	// Custom options with the fixed clock.
+	epoch := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := epoch.Add(1500 * time.Millisecond)
+
+	s, err = NewSnowflake(3, SnowflakeOptions{
+		Epoch:        epoch,
+		NodeBits:     4,
+		SequenceBits: 8,
+		Now:          func() time.Time { return now },
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		id, err := s.Next()
+		require.NoError(t, err)
+		assert.EqualValues(t, 1500<<12|3<<8|i, id)
+		assert.Equal(t, SnowflakeParts{Time: now, Node: 3, Sequence: int64(i)}, s.Decode(id))
+	}
+
+	for _, opts := range []SnowflakeOptions{
+		{NodeBits: 16, SequenceBits: 9},
+		{NodeBits: 4},
+		{Epoch: time.Now().Add(time.Hour)},
+	} {
+		_, err = NewSnowflake(16, opts)
+		require.Error(t, err, "options %+v", opts)
+	}
+
+	_, err = NewSnowflake(-1)
+	require.Error(t, err)
+
+	// Timestamp overflow.
+	s, err = NewSnowflake(0, SnowflakeOptions{
+		Epoch: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), NodeBits: 12, SequenceBits: 12,
+	})
+	require.NoError(t, err)
+
+	_, err = s.Next()
+	require.Error(t, err)
+
+	g := Utility{} // tests for method
+
+	s, err = g.NewSnowflake(1)
+	require.NoError(t, err)
+
+	id, err := s.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, s.Decode(id).Node)
+}
+
+func TestSnowflake_SequenceOverflow(t *testing.T) {
+	epoch := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// The clock advances by one millisecond after each 10 calls.
+	var calls atomic.Int64
+	now := func() time.Time {
+		return epoch.Add(time.Duration(calls.Add(1)/10) * time.Millisecond)
+	}
+
+	s, err := NewSnowflake(1, SnowflakeOptions{Epoch: epoch, NodeBits: 1, SequenceBits: 2, Now: now})
+	require.NoError(t, err)
+
+	prev := int64(-1)
+	for i := 0; i < 20; i++ {
+		id, err := s.Next()
+		require.NoError(t, err)
+		require.Greater(t, id, prev)
+		assert.LessOrEqual(t, s.Decode(id).Sequence, int64(3))
+		prev = id
+	}
+}
+
+func TestSnowflake_ClockRollback(t *testing.T) {
+	var offset atomic.Int64
+	now := func() time.Time {
+		return time.Now().Add(time.Duration(offset.Load()))
+	}
+
+	s, err := NewSnowflake(1, SnowflakeOptions{Now: now})
+	require.NoError(t, err)
+
+	_, err = s.Next()
+	require.NoError(t, err)
+
+	// Error by default.
+	offset.Store(int64(-time.Second))
+
+	_, err = s.Next()
+	require.ErrorIs(t, err, ErrClockRollback)
+
+	// Waiting for the clock within the allowed time.
+	s, err = NewSnowflake(1, SnowflakeOptions{Now: now, RollbackWait: time.Second})
+	require.NoError(t, err)
+
+	offset.Store(0)
+
+	prev, err := s.Next()
+	require.NoError(t, err)
+
+	offset.Store(int64(-20 * time.Millisecond))
+
+	id, err := s.Next()
+	require.NoError(t, err)
+	assert.Greater(t, id, prev)
+
+	offset.Store(int64(-time.Hour))
+
+	_, err = s.Next()
+	require.ErrorIs(t, err, ErrClockRollback)
+
+	// Time before the epoch.
+	s, err = NewSnowflake(1, SnowflakeOptions{Now: now})
+	require.NoError(t, err)
+
+	offset.Store(-int64(time.Since(snowflakeEpoch)) - int64(time.Hour))
+
+	_, err = s.Next()
+	require.ErrorIs(t, err, ErrClockRollback)
+}
+
+func TestSnowflake_Concurrent(t *testing.T) {
+	s, err := NewSnowflake(7)
+	require.NoError(t, err)
+
+	const goroutines, count = 8, 5000
+
+	results := make(chan []int64, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			ids := make([]int64, 0, count)
+			for j := 0; j < count; j++ {
+				id, err := s.Next()
+				if err != nil {
+					break
+				}
+				ids = append(ids, id)
+			}
+			results <- ids
+		}()
+	}
+
+	seen := make(map[int64]bool, goroutines*count)
+	for i := 0; i < goroutines; i++ {
+		ids := <-results
+		require.Len(t, ids, count)
+
+		for j, id := range ids {
+			require.False(t, seen[id], "duplicate ID %d", id)
+			seen[id] = true
+
+			if j > 0 {
+				require.Greater(t, id, ids[j-1])
+			}
+			assert.EqualValues(t, 7, s.Decode(id).Node)
+		}
+	}
+}
+
+func TestSnowflake_ConcurrentPreempted(t *testing.T) {
+	// Yield after reading the clock, so other goroutines can generate IDs with
+	// a later time in between.
+	s, err := NewSnowflake(7, SnowflakeOptions{Now: func() time.Time {
+		now := time.Now()
+		runtime.Gosched()
+
+		return now
+	}})
+	require.NoError(t, err)
+
+	const goroutines, count = 32, 2000
+
+	var errs atomic.Int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				if _, err := s.Next(); err != nil {
+					errs.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Zero(t, errs.Load())
+}
@@ -0,0 +1,443 @@
+package gosl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/knadh/koanf/parsers/toml"
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+// GenerateStruct generates Go struct definitions (with "json" and "koanf"
+// tags) from the given sample documents in the JSON, YAML or TOML format.
+//
+// Fields from all samples are merged: fields, which are missing (or null) in
+// some samples, are generated as optional (pointers with "omitempty" option).
+// Types are inferred from values: int, float64, bool, string, time.Time (for
+// RFC 3339 strings), nested structs and slices, or any for mixed values. The
+// result contains only type declarations (without package and imports).
+//
+// Keys, which can't be used as the name in the struct tags (with backticks or
+// commas, or the "-" key), return error.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		sample1 := []byte(`{"id":1,"name":"Viktor","tags":["admin"]}`)
+//		sample2 := []byte(`{"id":2,"name":"Anna","email":"anna@mail.com"}`)
+//
+//		src, err := gosl.GenerateStruct("User", "json", sample1, sample2)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(src)
+//	}
+func GenerateStruct(name, fileFormat string, samples ...[]byte) (string, error) {
+	// Check, if the name of the struct is valid.
+	name = goIdentifier(name)
+	if name == "" {
+		return "", errors.New("error: given name of the struct is empty")
+	}
+
+	// Check, if samples were given.
+	if len(samples) == 0 {
+		return "", errors.New("error: no sample documents were given")
+	}
+
+	root := &sampleType{}
+
+	// Parse and merge all sample documents.
+	for i, sample := range samples {
+		docs, err := parseSampleDocuments(fileFormat, sample)
+		if err != nil {
+			return "", fmt.Errorf("error parsing sample document #%d, %w", i+1, err)
+		}
+
+		for _, doc := range docs {
+			root.merge(doc)
+		}
+	}
+
+	// Check, if all documents are objects.
+	if root.kinds != sampleObject {
+		return "", errors.New("error: sample documents must be objects (or arrays of objects)")
+	}
+
+	g := &structGenerator{names: map[string]bool{}}
+	if err := g.generate(name, "", root); err != nil {
+		return "", err
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("error formatting generated source code, %w", err)
+	}
+
+	return string(bytes.TrimSpace(src)) + "\n", nil
+}
+
+// parseSampleDocuments helps to parse the sample document for the
+// GenerateStruct function. JSON samples can contain a top-level array of
+// objects or multiple documents (like JSON Lines).
+func parseSampleDocuments(fileFormat string, sample []byte) ([]any, error) {
+	switch strings.ToLower(strings.TrimPrefix(fileFormat, ".")) {
+	case "json", "jsonl", "ndjson":
+		docs := make([]any, 0, 1)
+		dec := json.NewDecoder(bytes.NewReader(sample))
+		dec.UseNumber()
+
+		for {
+			var doc any
+			if err := dec.Decode(&doc); err != nil {
+				if errors.Is(err, io.EOF) && len(docs) > 0 {
+					break
+				}
+				return nil, err
+			}
+
+			// Unwrap a top-level array of the documents.
+			if a, ok := doc.([]any); ok {
+				docs = append(docs, a...)
+			} else {
+				docs = append(docs, doc)
+			}
+		}
+
+		return docs, nil
+	case "yaml", "yml":
+		doc, err := yaml.Parser().Unmarshal(sample)
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{doc}, nil
+	case "toml":
+		doc, err := toml.Parser().Unmarshal(sample)
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{doc}, nil
+	default:
+		return nil, fmt.Errorf("error: unknown format of sample document (%s), use JSON, YAML, or TOML", fileFormat)
+	}
+}
+
+// Kinds of the sample values.
+const (
+	sampleNull uint8 = 1 << iota
+	sampleBool
+	sampleInt
+	sampleFloat
+	sampleString
+	sampleTime
+	sampleObject
+	sampleArray
+)
+
+// sampleType represents the merged type of the sample values.
+type sampleType struct {
+	kinds   uint8
+	objects int
+	fields  []*sampleField
+	elem    *sampleType
+}
+
+// sampleField represents the merged field of the sample objects.
+type sampleField struct {
+	key   string
+	count int
+	typ   *sampleType
+}
+
+// merge merges the given sample value into the type.
+func (t *sampleType) merge(v any) {
+	switch value := v.(type) {
+	case nil:
+		t.kinds |= sampleNull
+	case bool:
+		t.kinds |= sampleBool
+	case json.Number:
+		if _, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			t.kinds |= sampleInt
+		} else {
+			t.kinds |= sampleFloat
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		t.kinds |= sampleInt
+	case float32, float64:
+		t.kinds |= sampleFloat
+	case time.Time:
+		t.kinds |= sampleTime
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			t.kinds |= sampleTime
+		} else {
+			t.kinds |= sampleString
+		}
+	case map[string]any:
+		t.kinds |= sampleObject
+		t.objects++
+
+		// Merge fields in the sorted order of the keys.
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			var f *sampleField
+			for _, existing := range t.fields {
+				if existing.key == key {
+					f = existing
+					break
+				}
+			}
+
+			if f == nil {
+				f = &sampleField{key: key, typ: &sampleType{}}
+				t.fields = append(t.fields, f)
+			}
+
+			if value[key] != nil {
+				f.count++
+			}
+			f.typ.merge(value[key])
+		}
+	case []any:
+		t.kinds |= sampleArray
+		if t.elem == nil {
+			t.elem = &sampleType{}
+		}
+
+		for _, elem := range value {
+			t.elem.merge(elem)
+		}
+	default:
+		t.kinds |= sampleString
+	}
+}
+
+// structGenerator represents a generator of the Go source code for the
+// GenerateStruct function.
+type structGenerator struct {
+	buf     bytes.Buffer
+	names   map[string]bool
+	pending []pendingStruct
+}
+
+// pendingStruct represents a nested struct, waiting for generation.
+type pendingStruct struct {
+	name string
+	typ  *sampleType
+}
+
+// generate writes the struct with the given name and all nested structs.
+func (g *structGenerator) generate(name, parent string, t *sampleType) error {
+	g.pending = append(g.pending, pendingStruct{name: g.uniqueName(name, parent), typ: t})
+
+	for len(g.pending) > 0 {
+		s := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.writeStruct(s.name, s.typ); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueName returns the unique name for a new struct type.
+func (g *structGenerator) uniqueName(name, parent string) string {
+	candidate := name
+	if g.names[candidate] && parent != "" {
+		candidate = parent + name
+	}
+
+	for i := 2; g.names[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	g.names[candidate] = true
+
+	return candidate
+}
+
+// writeStruct writes the struct definition with the given name.
+func (g *structGenerator) writeStruct(name string, t *sampleType) error {
+	fmt.Fprintf(&g.buf, "// %s represents struct, generated from the sample documents.\n", name)
+	fmt.Fprintf(&g.buf, "type %s struct {\n", name)
+
+	fieldNames := map[string]bool{}
+
+	for _, f := range t.fields {
+		if err := validateTagKey(f.key); err != nil {
+			return err
+		}
+
+		fieldName := goIdentifier(f.key)
+		if fieldName == "" {
+			fieldName = "Field"
+		}
+
+		base := fieldName
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = base + strconv.Itoa(i)
+		}
+		fieldNames[fieldName] = true
+
+		optional := f.count < t.objects
+		typ := g.goType(fieldName, name, f.typ, optional)
+
+		jsonTag := f.key
+		if optional {
+			jsonTag += ",omitempty"
+		}
+
+		fmt.Fprintf(&g.buf, "%s %s `json:%q koanf:%q`\n", fieldName, typ, jsonTag, f.key)
+	}
+
+	g.buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// validateTagKey checks, if the key of the sample document can be used as the
+// name in the struct tags: backticks break the raw string of the tags, commas
+// are separators of the tag options and "-" skips the field.
+func validateTagKey(key string) error {
+	switch {
+	case key == "-":
+		return errors.New("error: key (-) can't be used as the name in the struct tags, because it skips the field")
+	case strings.ContainsRune(key, '`'):
+		return fmt.Errorf("error: key (%s) can't be used as the name in the struct tags, because it contains a backtick", key)
+	case strings.ContainsRune(key, ','):
+		return fmt.Errorf("error: key (%s) can't be used as the name in the struct tags, because it contains a comma", key)
+	default:
+		return nil
+	}
+}
+
+// goType returns the Go type for the merged sample type.
+func (g *structGenerator) goType(fieldName, parent string, t *sampleType, optional bool) string {
+	kinds := t.kinds &^ sampleNull
+
+	var typ string
+	switch kinds {
+	case sampleBool:
+		typ = "bool"
+	case sampleInt:
+		typ = "int"
+	case sampleInt | sampleFloat, sampleFloat:
+		typ = "float64"
+	case sampleString, sampleString | sampleTime:
+		typ = "string"
+	case sampleTime:
+		typ = "time.Time"
+	case sampleObject:
+		name := g.uniqueName(fieldName, parent)
+		g.pending = append(g.pending, pendingStruct{name: name, typ: t})
+		typ = name
+	case sampleArray:
+		elem := "any"
+		if t.elem != nil && t.elem.kinds&^sampleNull != 0 {
+			elem = g.goType(singularName(fieldName), parent, t.elem, t.elem.kinds&sampleNull != 0)
+		}
+		return "[]" + elem // slices are nil-able, no pointer is needed
+	default:
+		return "any"
+	}
+
+	if optional {
+		return "*" + typ
+	}
+
+	return typ
+}
+
+// singularName returns a naive singular form of the name for the struct types
+// of the slice elements.
+func singularName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ses"), strings.HasSuffix(name, "xes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return name[:len(name)-1]
+	default:
+		return name + "Item"
+	}
+}
+
+// commonInitialisms represents a list of the common initialisms for the Go
+// identifiers.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "LHS": true, "QPS": true, "RAM": true, "RHS": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true, "XMPP": true,
+	"XSRF": true, "XSS": true,
+}
+
+// goIdentifier converts the given key to the exported Go identifier with the
+// common initialisms (for ex., "user_id" to "UserID").
+func goIdentifier(key string) string {
+	var b strings.Builder
+
+	for _, word := range splitWords(key) {
+		upper := strings.ToUpper(word)
+		if commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	s := b.String()
+	if s != "" && !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s // identifiers can't start with a digit
+	}
+
+	return s
+}
+
+// splitWords splits the given string into words by separators and by case
+// changes (for ex., "HTTPServer_url" to "HTTP", "Server", "url").
+func splitWords(s string) []string {
+	var words []string
+
+	for i := 0; ; {
+		start, end := nextWord(s, i)
+		if start < 0 {
+			break
+		}
+
+		words = append(words, s[start:end])
+		i = end
+	}
+
+	return words
+}
@@ -0,0 +1,158 @@
+package gosl
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resultStructGen string
+
+func BenchmarkGenerateStruct_JSON(b *testing.B) {
+	sample := []byte(`{"id":1,"name":"Viktor","email":"my@mail.com","tags":["admin"],"address":{"city":"Moscow"}}`)
+
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = GenerateStruct("User", "json", sample)
+	}
+	resultStructGen = r
+}
+
+func TestGenerateStruct(t *testing.T) {
+	sample1 := []byte(`{
+	"id": 1,
+	"user_name": "Viktor",
+	"score": 10,
+	"active": true,
+	"created_at": "2023-05-01T12:30:00Z",
+	"server_url": "https://example.com",
+	"tags": ["admin", "user"],
+	"address": {"city": "Moscow", "zip": "101000"},
+	"orders": [{"id": 1, "total": 9.99}],
+	"extra": null
+}`)
+	sample2 := []byte(`[{
+	"id": 2,
+	"user_name": "Anna",
+	"score": 10.5,
+	"active": false,
+	"created_at": "2023-05-02T12:30:00Z",
+	"server_url": "https://example.com",
+	"tags": [],
+	"address": {"city": "Berlin"},
+	"orders": [{"id": 2, "total": 5, "note": "gift"}],
+	"email": "anna@mail.com",
+	"mixed": 1
+}, {
+	"id": 3,
+	"user_name": "Olga",
+	"score": 1,
+	"active": true,
+	"created_at": "2023-05-03T12:30:00Z",
+	"server_url": "https://example.com",
+	"address": {"city": "Paris"},
+	"orders": [],
+	"mixed": "one"
+}]`)
+
+	expected := `// User represents struct, generated from the sample documents.
+type User struct {
+	Active    bool      ` + "`" + `json:"active" koanf:"active"` + "`" + `
+	Address   Address   ` + "`" + `json:"address" koanf:"address"` + "`" + `
+	CreatedAt time.Time ` + "`" + `json:"created_at" koanf:"created_at"` + "`" + `
+	Extra     any       ` + "`" + `json:"extra,omitempty" koanf:"extra"` + "`" + `
+	ID        int       ` + "`" + `json:"id" koanf:"id"` + "`" + `
+	Orders    []Order   ` + "`" + `json:"orders" koanf:"orders"` + "`" + `
+	Score     float64   ` + "`" + `json:"score" koanf:"score"` + "`" + `
+	ServerURL string    ` + "`" + `json:"server_url" koanf:"server_url"` + "`" + `
+	Tags      []string  ` + "`" + `json:"tags,omitempty" koanf:"tags"` + "`" + `
+	UserName  string    ` + "`" + `json:"user_name" koanf:"user_name"` + "`" + `
+	Email     *string   ` + "`" + `json:"email,omitempty" koanf:"email"` + "`" + `
+	Mixed     any       ` + "`" + `json:"mixed,omitempty" koanf:"mixed"` + "`" + `
+}
+
+// Address represents struct, generated from the sample documents.
+type Address struct {
+	City string  ` + "`" + `json:"city" koanf:"city"` + "`" + `
+	Zip  *string ` + "`" + `json:"zip,omitempty" koanf:"zip"` + "`" + `
+}
+
+// Order represents struct, generated from the sample documents.
+type Order struct {
+	ID    int     ` + "`" + `json:"id" koanf:"id"` + "`" + `
+	Total float64 ` + "`" + `json:"total" koanf:"total"` + "`" + `
+	Note  *string ` + "`" + `json:"note,omitempty" koanf:"note"` + "`" + `
+}
+`
+
+	src, err := GenerateStruct("user", "json", sample1, sample2)
+	require.NoError(t, err)
+	assert.Equal(t, expected, src)
+
+	_, err = GenerateStruct("", "json", sample1)
+	require.Error(t, err)
+
+	_, err = GenerateStruct("User", "json")
+	require.Error(t, err)
+
+	_, err = GenerateStruct("User", "xml", sample1)
+	require.Error(t, err)
+
+	_, err = GenerateStruct("User", "json", []byte(`{"id":`))
+	require.Error(t, err)
+
+	_, err = GenerateStruct("User", "json", []byte(`[1, 2, 3]`))
+	require.Error(t, err)
+
+	// Keys, which can't be used as the name in the struct tags.
+	for key, msg := range map[string]string{
+		"-":          "skips the field",
+		"a`b":        "contains a backtick",
+		"first,last": "contains a comma",
+	} {
+		_, err = GenerateStruct("User", "json", []byte(`{"id":1,"nested":{`+strconv.Quote(key)+`:1}}`))
+		require.ErrorContains(t, err, msg, "key %s", key)
+	}
+
+	g := Utility{} // tests for method
+
+	src, err = g.GenerateStruct("user", "json", sample1, sample2)
+	require.NoError(t, err)
+	assert.Equal(t, expected, src)
+}
+
+func TestGenerateStruct_YAMLAndTOML(t *testing.T) {
+	sampleYAML := []byte(`
+host: localhost
+port: 3000
+timeout: 1.5
+started_at: 2023-05-01T12:30:00Z
+database:
+  dsn: postgres://localhost
+  pool_size: 10
+`)
+	sampleTOML := []byte(`
+host = "example.com"
+port = 8080
+timeout = 2.0
+started_at = 2023-05-02T12:30:00Z
+
+[database]
+dsn = "postgres://example.com"
+pool_size = 20
+`)
+
+	srcYAML, err := GenerateStruct("Config", "yaml", sampleYAML)
+	require.NoError(t, err)
+
+	srcTOML, err := GenerateStruct("Config", ".toml", sampleTOML)
+	require.NoError(t, err)
+
+	assert.Equal(t, srcYAML, srcTOML)
+	assert.Contains(t, srcYAML, "Database  Database  `json:\"database\" koanf:\"database\"`")
+	assert.Contains(t, srcYAML, "PoolSize int    `json:\"pool_size\" koanf:\"pool_size\"`")
+	assert.Contains(t, srcYAML, "StartedAt time.Time `json:\"started_at\" koanf:\"started_at\"`")
+	assert.Contains(t, srcYAML, "Timeout   float64   `json:\"timeout\" koanf:\"timeout\"`")
+}
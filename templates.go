@@ -0,0 +1,290 @@
+package gosl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// MissingKeyPolicy represents a policy for the placeholders, which keys are
+// missing in the values of the Template.
+type MissingKeyPolicy uint8
+
+// Policies for the missing keys.
+const (
+	// MissingKeyError returns an error for the missing key (by default).
+	MissingKeyError MissingKeyPolicy = iota
+
+	// MissingKeyKeep keeps the placeholder as is (for ex., "{name}").
+	MissingKeyKeep
+
+	// MissingKeyEmpty replaces the placeholder with an empty string.
+	MissingKeyEmpty
+)
+
+// TemplateOptions represents options for the Template.
+type TemplateOptions struct {
+	// MissingKey sets a policy for the missing keys (MissingKeyError by
+	// default).
+	MissingKey MissingKeyPolicy
+}
+
+// Template represents a compiled pattern with the named placeholders (for ex.,
+// "Hello, {name}!") for the string interpolation.
+//
+// Placeholders can contain a format verb of the "fmt" package after the colon
+// (for ex., "{price:%.2f}"). Use "{{" and "}}" to escape braces in the pattern.
+//
+// Template is safe for concurrent use by multiple goroutines.
+type Template struct {
+	pattern    string
+	parts      []templatePart
+	literalLen int
+	missingKey MissingKeyPolicy
+}
+
+// templatePart represents a literal text or a placeholder of the Template.
+type templatePart struct {
+	text        string // literal text or the original placeholder
+	key         string
+	verb        string
+	placeholder bool
+}
+
+// CompileTemplate parses the given pattern with the named placeholders to the
+// Template, which can be rendered many times.
+//
+// If err != nil returns nil and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		t, err := gosl.CompileTemplate("Hello, {name}! Total: {price:%.2f}")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		s, err := t.Render(map[string]any{"name": "Viktor", "price": 9.5})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // Hello, Viktor! Total: 9.50
+//	}
+func CompileTemplate(pattern string, opts ...TemplateOptions) (*Template, error) {
+	t := &Template{pattern: pattern}
+	if len(opts) > 0 {
+		t.missingKey = opts[0].MissingKey
+	}
+
+	var literal []byte
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '{':
+			// Check, if the brace is escaped.
+			if i+1 < len(pattern) && pattern[i+1] == '{' {
+				literal = append(literal, '{')
+				i++
+				continue
+			}
+
+			end := strings.IndexByte(pattern[i+1:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("error: unclosed placeholder at position %d in the pattern", i)
+			}
+
+			part := templatePart{text: pattern[i : i+end+2], placeholder: true}
+			part.key, part.verb, _ = strings.Cut(pattern[i+1:i+end+1], ":")
+
+			if part.key == "" {
+				return nil, fmt.Errorf("error: empty key of the placeholder at position %d in the pattern", i)
+			}
+			if strings.IndexByte(part.key, '{') >= 0 {
+				return nil, fmt.Errorf("error: invalid key of the placeholder at position %d in the pattern", i)
+			}
+			if part.verb != "" && part.verb[0] != '%' {
+				return nil, fmt.Errorf("error: invalid format verb (%s) of the placeholder at position %d in the pattern", part.verb, i)
+			}
+
+			if len(literal) > 0 {
+				t.parts = append(t.parts, templatePart{text: string(literal)})
+				t.literalLen += len(literal)
+				literal = literal[:0]
+			}
+
+			t.parts = append(t.parts, part)
+			i += end + 1
+		case '}':
+			// Check, if the brace is escaped.
+			if i+1 < len(pattern) && pattern[i+1] == '}' {
+				literal = append(literal, '}')
+				i++
+				continue
+			}
+
+			return nil, fmt.Errorf("error: unexpected '}' at position %d in the pattern, use '}}' to escape it", i)
+		default:
+			literal = append(literal, c)
+		}
+	}
+
+	if len(literal) > 0 {
+		t.parts = append(t.parts, templatePart{text: string(literal)})
+		t.literalLen += len(literal)
+	}
+
+	return t, nil
+}
+
+// Render renders the Template with the given values of the placeholders with a
+// single allocation (for the most of the value types).
+//
+// If err != nil returns zero-value for a string and error.
+func (t *Template) Render(values map[string]any) (string, error) {
+	// Estimate the length of the result.
+	n := t.literalLen
+	for i := range t.parts {
+		if !t.parts[i].placeholder {
+			continue
+		}
+
+		switch v := values[t.parts[i].key].(type) {
+		case string:
+			if t.parts[i].verb == "" {
+				n += len(v)
+			} else {
+				n += len(v) + 16
+			}
+		default:
+			n += 24
+		}
+	}
+
+	buf := make([]byte, 0, n)
+
+	for i := range t.parts {
+		part := &t.parts[i]
+		if !part.placeholder {
+			buf = append(buf, part.text...)
+			continue
+		}
+
+		v, ok := values[part.key]
+		if !ok {
+			switch t.missingKey {
+			case MissingKeyKeep:
+				buf = append(buf, part.text...)
+			case MissingKeyEmpty:
+			default:
+				return "", fmt.Errorf("error: value for the key (%s) is missing", part.key)
+			}
+			continue
+		}
+
+		if part.verb != "" {
+			buf = fmt.Appendf(buf, part.verb, v)
+			continue
+		}
+
+		buf = appendTemplateValue(buf, v)
+	}
+
+	if len(buf) == 0 {
+		return "", nil
+	}
+
+	return unsafe.String(unsafe.SliceData(buf), len(buf)), nil
+}
+
+// String returns the original pattern of the Template.
+func (t *Template) String() string {
+	return t.pattern
+}
+
+// appendTemplateValue appends the given value to the buffer in the default
+// format (like the "%v" verb of the "fmt" package).
+func appendTemplateValue(buf []byte, v any) []byte {
+	switch value := v.(type) {
+	case string:
+		return append(buf, value...)
+	case int:
+		return strconv.AppendInt(buf, int64(value), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(value), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(value), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(value), 10)
+	case int64:
+		return strconv.AppendInt(buf, value, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(value), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(value), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(value), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(value), 10)
+	case uint64:
+		return strconv.AppendUint(buf, value, 10)
+	case float32:
+		return strconv.AppendFloat(buf, float64(value), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(buf, value, 'g', -1, 64)
+	case bool:
+		return strconv.AppendBool(buf, value)
+	default:
+		return fmt.Append(buf, value)
+	}
+}
+
+// Interpolate replaces the named placeholders (for ex., "{name}") in the given
+// pattern with the values. For rendering the same pattern many times, use the
+// compiled Template (see CompileTemplate).
+//
+// Placeholders can contain a format verb of the "fmt" package after the colon
+// (for ex., "{price:%.2f}"). Use "{{" and "}}" to escape braces in the pattern.
+//
+// If err != nil returns zero-value for a string and error.
+//
+// Example:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/koddr/gosl"
+//	)
+//
+//	func main() {
+//		s, err := gosl.Interpolate(
+//			"Hello, {name}! You have {count} items",
+//			map[string]any{"name": "Viktor", "count": 3},
+//		)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		fmt.Println(s) // Hello, Viktor! You have 3 items
+//	}
+func Interpolate(pattern string, values map[string]any, opts ...TemplateOptions) (string, error) {
+	t, err := CompileTemplate(pattern, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Render(values)
+}
@@ -0,0 +1,164 @@
+package gosl
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resultTemplate string
+
+func BenchmarkInterpolate(b *testing.B) {
+	values := map[string]any{"name": "Viktor", "count": 3, "price": 9.5}
+
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = Interpolate("Hello, {name}! You have {count} items for {price:%.2f}", values)
+	}
+	resultTemplate = r
+}
+
+func BenchmarkTemplate_Render(b *testing.B) {
+	t, _ := CompileTemplate("Hello, {name}! You have {count} items for {price:%.2f}")
+	values := map[string]any{"name": "Viktor", "count": 3, "price": 9.5}
+
+	b.ReportAllocs()
+	var r string
+	for i := 0; i < b.N; i++ {
+		r, _ = t.Render(values)
+	}
+	resultTemplate = r
+}
+
+func TestInterpolate(t *testing.T) {
+	values := map[string]any{
+		"name":     "Viktor",
+		"count":    3,
+		"price":    9.5,
+		"ok":       true,
+		"uint":     uint8(7),
+		"float32":  float32(0.1),
+		"duration": 2 * time.Second,
+		"err":      errors.New("failed"),
+		"nil":      nil,
+	}
+
+	for _, tc := range []struct {
+		pattern  string
+		expected string
+	}{
+		{"", ""},
+		{"Hello!", "Hello!"},
+		{"Hello, {name}! You have {count} items", "Hello, Viktor! You have 3 items"},
+		{"{name}{name}", "ViktorViktor"},
+		{"Total: {price:%.2f}", "Total: 9.50"},
+		{"Total: {price}", "Total: 9.5"},
+		{"{name:%q} {count:%03d} {name:%8s}|", "\"Viktor\" 003   Viktor|"},
+		{"{ok} {uint} {float32}", "true 7 0.1"},
+		{"{duration} {err} {nil}", "2s failed <nil>"},
+		{"{{name}} is {name}", "{name} is Viktor"},
+		{"}}{{{name}}}", "}{Viktor}"},
+		{"Привет, {name}!", "Привет, Viktor!"},
+	} {
+		s, err := Interpolate(tc.pattern, values)
+		require.NoError(t, err, "pattern %s", tc.pattern)
+		assert.Equal(t, tc.expected, s, "pattern %s", tc.pattern)
+	}
+
+	for _, pattern := range []string{
+		"Hello, {name",
+		"Hello, name}",
+		"Hello, {}",
+		"Hello, {:%d}",
+		"Hello, {na{me}",
+		"Hello, {name:d}",
+		"Hello, {unknown}",
+	} {
+		_, err := Interpolate(pattern, values)
+		assert.Error(t, err, "pattern %s", pattern)
+	}
+
+	g := Utility{} // tests for method
+
+	s, err := g.Interpolate("Hello, {name}!", values)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Viktor!", s)
+}
+
+func TestInterpolate_MissingKey(t *testing.T) {
+	pattern := "Hello, {name}! Total: {price:%.2f}"
+	values := map[string]any{"name": "Viktor"}
+
+	_, err := Interpolate(pattern, values)
+	require.Error(t, err)
+
+	_, err = Interpolate(pattern, values, TemplateOptions{MissingKey: MissingKeyError})
+	require.Error(t, err)
+
+	s, err := Interpolate(pattern, values, TemplateOptions{MissingKey: MissingKeyKeep})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Viktor! Total: {price:%.2f}", s)
+
+	s, err = Interpolate(pattern, values, TemplateOptions{MissingKey: MissingKeyEmpty})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Viktor! Total: ", s)
+
+	s, err = Interpolate(pattern, nil, TemplateOptions{MissingKey: MissingKeyEmpty})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, ! Total: ", s)
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := CompileTemplate("Hello, {name}! You have {count} items for {price:%.2f}")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, {name}! You have {count} items for {price:%.2f}", tmpl.String())
+
+	values := map[string]any{"name": "Viktor", "count": 3, "price": 9.5}
+
+	s, err := tmpl.Render(values)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Viktor! You have 3 items for 9.50", s)
+
+	// One allocation per render.
+	allocs := testing.AllocsPerRun(100, func() {
+		s, _ = tmpl.Render(values)
+	})
+	assert.EqualValues(t, 1, allocs)
+
+	// Values longer than the estimated length.
+	long := map[string]any{"name": "Viktor", "count": "many many many many many many items", "price": 9.5}
+
+	s, err = tmpl.Render(long)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Viktor! You have many many many many many many items items for 9.50", s)
+
+	// Concurrent renders.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := tmpl.Render(values)
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, Viktor! You have 3 items for 9.50", r)
+		}()
+	}
+	wg.Wait()
+
+	g := Utility{} // tests for method
+
+	tmpl, err = g.CompileTemplate("{a}-{b}", TemplateOptions{MissingKey: MissingKeyKeep})
+	require.NoError(t, err)
+
+	s, err = tmpl.Render(map[string]any{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "1-{b}", s)
+
+	_, err = g.CompileTemplate("{a")
+	require.Error(t, err)
+}